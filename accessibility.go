@@ -0,0 +1,98 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/ecwid/control/protocol/accessibility"
+	"github.com/ecwid/control/protocol/dom"
+)
+
+// AccessibilityNode node of accessibility tree
+type AccessibilityNode struct {
+	Role          string
+	Name          string
+	Description   string
+	Value         string
+	Ignored       bool
+	Properties    map[string]interface{} // e.g. focusable, checked, level
+	BackendNodeID dom.BackendNodeId
+	Children      []*AccessibilityNode
+}
+
+// uninterestingRoles roles of nodes that are only containers and do not expose semantics
+var uninterestingRoles = map[string]bool{"generic": true, "none": true, "presentation": true, "InlineTextBox": true, "LineBreak": true}
+
+func axString(v *accessibility.AXValue) string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(v.Value)
+}
+
+func newAccessibilityNode(v *accessibility.AXNode) *AccessibilityNode {
+	var node = &AccessibilityNode{
+		Role:          axString(v.Role),
+		Name:          axString(v.Name),
+		Description:   axString(v.Description),
+		Value:         axString(v.Value),
+		Ignored:       v.Ignored,
+		BackendNodeID: v.BackendDOMNodeId,
+	}
+	if len(v.Properties) > 0 {
+		node.Properties = make(map[string]interface{}, len(v.Properties))
+		for _, p := range v.Properties {
+			if p.Value != nil {
+				node.Properties[string(p.Name)] = p.Value.Value
+			}
+		}
+	}
+	return node
+}
+
+// interesting node has semantics: it's not ignored and has meaningful role, name, value or it is focusable
+func (n AccessibilityNode) interesting() bool {
+	if n.Ignored {
+		return false
+	}
+	if focusable, _ := n.Properties["focusable"].(bool); focusable {
+		return true
+	}
+	return !uninterestingRoles[n.Role] || n.Name != "" || n.Value != ""
+}
+
+// AccessibilitySnapshot returns accessibility tree of the page, if interestingOnly then ignored nodes and
+// nodes without semantics (generic containers) are omitted and their children are attached to the nearest kept ancestor
+func (s Session) AccessibilitySnapshot(interestingOnly bool) (*AccessibilityNode, error) {
+	val, err := accessibility.GetFullAXTree(s, accessibility.GetFullAXTreeArgs{})
+	if err != nil {
+		return nil, err
+	}
+	if len(val.Nodes) == 0 {
+		return nil, nil
+	}
+	var (
+		byID  = make(map[accessibility.AXNodeId]*accessibility.AXNode, len(val.Nodes))
+		build func(v *accessibility.AXNode, root bool) []*AccessibilityNode
+	)
+	for _, v := range val.Nodes {
+		byID[v.NodeId] = v
+	}
+	build = func(v *accessibility.AXNode, root bool) []*AccessibilityNode {
+		var (
+			node     = newAccessibilityNode(v)
+			children []*AccessibilityNode
+		)
+		for _, id := range v.ChildIds {
+			if child, ok := byID[id]; ok {
+				children = append(children, build(child, false)...)
+			}
+		}
+		if interestingOnly && !root && !node.interesting() {
+			return children
+		}
+		node.Children = children
+		return []*AccessibilityNode{node}
+	}
+	// the first node is root web area of the main frame
+	return build(val.Nodes[0], true)[0], nil
+}
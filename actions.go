@@ -0,0 +1,291 @@
+package control
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/ecwid/control/protocol/input"
+)
+
+// Modifier keys bit field of input events
+const (
+	ModifierAlt   = 1
+	ModifierCtrl  = 2
+	ModifierMeta  = 4
+	ModifierShift = 8
+)
+
+var (
+	KeyShift   = KeyDefinition{KeyCode: 16, Key: "Shift", Code: "ShiftLeft", Location: 1}
+	KeyControl = KeyDefinition{KeyCode: 17, Key: "Control", Code: "ControlLeft", Location: 1}
+	KeyAlt     = KeyDefinition{KeyCode: 18, Key: "Alt", Code: "AltLeft", Location: 1}
+	KeyMeta    = KeyDefinition{KeyCode: 91, Key: "Meta", Code: "MetaLeft", Location: 1}
+)
+
+var mouseButtons = map[input.MouseButton]int{MouseLeft: 1, MouseRight: 2, MouseMiddle: 4, MouseBack: 8, MouseForward: 16}
+
+func modifierOf(key KeyDefinition) int {
+	switch key.Key {
+	case KeyAlt.Key:
+		return ModifierAlt
+	case KeyControl.Key:
+		return ModifierCtrl
+	case KeyMeta.Key:
+		return ModifierMeta
+	case KeyShift.Key:
+		return ModifierShift
+	}
+	return 0
+}
+
+// keyID identity of the physical key regardless of Shift
+func keyID(key KeyDefinition) string {
+	if key.Code != "" {
+		return key.Code
+	}
+	return key.Key
+}
+
+// actionState pointer and keyboard state of performed chain
+type actionState struct {
+	x, y      float64
+	buttons   int
+	button    input.MouseButton // last pressed button
+	modifiers int
+	pressed   map[string]KeyDefinition // keys held down as they were dispatched, see keyID
+}
+
+// Actions chain of input actions performed as one serialized sequence, see Input.Actions.
+// Keys and buttons left pressed are released by Perform if the chain fails
+type Actions struct {
+	i     Input
+	steps []func(*actionState) error
+}
+
+// Actions returns empty chain of actions, e.g. shift-click-drag selection:
+// Input.Actions().MoveTo(x1, y1).KeyDown(KeyShift).MouseDown(MouseLeft).MoveTo(x2, y2).MouseUp(MouseLeft).KeyUp(KeyShift).Perform()
+func (i Input) Actions() *Actions {
+	return &Actions{i: i}
+}
+
+func (a *Actions) add(step func(*actionState) error) *Actions {
+	a.steps = append(a.steps, step)
+	return a
+}
+
+func (a *Actions) mouse(state *actionState, kind string, button input.MouseButton, clickCount int) error {
+	a.i.pointer.moved(state.x, state.y)
+	return input.DispatchMouseEvent(a.i.s, input.DispatchMouseEventArgs{
+		Type:       kind,
+		X:          state.x,
+		Y:          state.y,
+		Modifiers:  state.modifiers,
+		Button:     button,
+		Buttons:    state.buttons,
+		ClickCount: clickCount,
+	})
+}
+
+func (a *Actions) move(state *actionState, x, y float64) error {
+	state.x, state.y = x, y
+	var button = MouseNone
+	if state.buttons != 0 {
+		button = state.button
+	}
+	return a.mouse(state, "mouseMoved", button, 0)
+}
+
+// MoveTo move pointer to the point of the viewport
+func (a *Actions) MoveTo(x, y float64) *Actions {
+	return a.add(func(state *actionState) error {
+		return a.move(state, x, y)
+	})
+}
+
+// MoveBy move pointer relative to its current position
+func (a *Actions) MoveBy(dx, dy float64) *Actions {
+	return a.add(func(state *actionState) error {
+		return a.move(state, state.x+dx, state.y+dy)
+	})
+}
+
+// MoveToElement scroll element into view and move pointer to its middle, position is resolved when the chain is performed
+func (a *Actions) MoveToElement(e *Element) *Actions {
+	return a.add(func(state *actionState) error {
+		if err := e.ScrollIntoView(); err != nil {
+			return err
+		}
+		x, y, err := e.clickablePoint()
+		if err != nil {
+			return err
+		}
+		return a.move(state, x, y)
+	})
+}
+
+// MoveThrough move pointer through waypoints, each segment is split into steps intermediate moves
+func (a *Actions) MoveThrough(steps int, waypoints ...Point) *Actions {
+	if steps < 1 {
+		steps = 1
+	}
+	return a.add(func(state *actionState) error {
+		for _, p := range waypoints {
+			var x0, y0 = state.x, state.y
+			for n := 1; n <= steps; n++ {
+				var t = float64(n) / float64(steps)
+				if err := a.move(state, x0+(p.X-x0)*t, y0+(p.Y-y0)*t); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// MouseDown press mouse button at current pointer position
+func (a *Actions) MouseDown(button input.MouseButton) *Actions {
+	return a.add(func(state *actionState) error {
+		state.buttons |= mouseButtons[button]
+		state.button = button
+		return a.mouse(state, "mousePressed", button, 1)
+	})
+}
+
+// MouseUp release mouse button at current pointer position
+func (a *Actions) MouseUp(button input.MouseButton) *Actions {
+	return a.add(func(state *actionState) error {
+		state.buttons &^= mouseButtons[button]
+		return a.mouse(state, "mouseReleased", button, 1)
+	})
+}
+
+// Click press and release mouse button at current pointer position
+func (a *Actions) Click(button input.MouseButton) *Actions {
+	return a.MouseDown(button).MouseUp(button)
+}
+
+// KeyDown press key, modifier keys (KeyShift, KeyControl, KeyAlt, KeyMeta) modify the following events:
+// Shift gives shifted key and Control, Alt or Meta make a shortcut that doesn't insert text
+func (a *Actions) KeyDown(key KeyDefinition) *Actions {
+	return a.add(func(state *actionState) error {
+		var key, id = key, keyID(key)
+		if state.modifiers&ModifierShift != 0 {
+			key = shiftedKey(key)
+		}
+		state.pressed[id] = key
+		state.modifiers |= modifierOf(key)
+		var text = key.Text
+		if text == "" && utf8.RuneCountInString(key.Key) == 1 {
+			text = key.Key
+		}
+		if state.modifiers&^ModifierShift != 0 {
+			text = ""
+		}
+		var kind = dispatchKeyEventKeyDown
+		if text == "" {
+			kind = "rawKeyDown"
+		}
+		return input.DispatchKeyEvent(a.i.s, input.DispatchKeyEventArgs{
+			Type:                  kind,
+			Modifiers:             state.modifiers,
+			Key:                   key.Key,
+			Code:                  key.Code,
+			WindowsVirtualKeyCode: key.KeyCode,
+			Text:                  text,
+			Location:              key.Location,
+		})
+	})
+}
+
+// KeyUp release key, the key is released as it was pressed by KeyDown even if Shift has changed since
+func (a *Actions) KeyUp(key KeyDefinition) *Actions {
+	return a.add(func(state *actionState) error {
+		var key = key
+		if down, ok := state.pressed[keyID(key)]; ok {
+			delete(state.pressed, keyID(key))
+			key = down
+		} else if state.modifiers&ModifierShift != 0 {
+			key = shiftedKey(key)
+		}
+		state.modifiers &^= modifierOf(key)
+		return input.DispatchKeyEvent(a.i.s, input.DispatchKeyEventArgs{
+			Type:                  dispatchKeyEventKeyUp,
+			Modifiers:             state.modifiers,
+			Key:                   key.Key,
+			Code:                  key.Code,
+			WindowsVirtualKeyCode: key.KeyCode,
+			Location:              key.Location,
+		})
+	})
+}
+
+// Wheel dispatch mouse wheel event at current pointer position
+func (a *Actions) Wheel(deltaX, deltaY float64) *Actions {
+	return a.add(func(state *actionState) error {
+		return input.DispatchMouseEvent(a.i.s, input.DispatchMouseEventArgs{
+			Type:      "mouseWheel",
+			X:         state.x,
+			Y:         state.y,
+			Modifiers: state.modifiers,
+			DeltaX:    deltaX,
+			DeltaY:    deltaY,
+		})
+	})
+}
+
+// Pause wait between actions
+func (a *Actions) Pause(d time.Duration) *Actions {
+	return a.add(func(*actionState) error {
+		a.i.s.Clock().Sleep(d)
+		return nil
+	})
+}
+
+// release buttons and keys left pressed by failed chain, ordinary keys are released before modifiers
+func (a *Actions) release(state *actionState) {
+	for button, bit := range mouseButtons {
+		if state.buttons&bit != 0 {
+			state.buttons &^= bit
+			_ = a.mouse(state, "mouseReleased", button, 1)
+		}
+	}
+	var keyUp = func(id string, key KeyDefinition) {
+		delete(state.pressed, id)
+		state.modifiers &^= modifierOf(key)
+		_ = input.DispatchKeyEvent(a.i.s, input.DispatchKeyEventArgs{
+			Type:                  dispatchKeyEventKeyUp,
+			Modifiers:             state.modifiers,
+			Key:                   key.Key,
+			Code:                  key.Code,
+			WindowsVirtualKeyCode: key.KeyCode,
+			Location:              key.Location,
+		})
+	}
+	for id, key := range state.pressed {
+		if modifierOf(key) == 0 {
+			keyUp(id, key)
+		}
+	}
+	for id, key := range state.pressed {
+		keyUp(id, key)
+	}
+}
+
+// Perform perform the chain under input lock of the session (as Input.Click), pointer starts at
+// the last known pointer position of the session
+func (a *Actions) Perform() error {
+	a.i.s.slowDown()
+	a.i.mx.Lock()
+	defer a.i.mx.Unlock()
+	var state = &actionState{pressed: map[string]KeyDefinition{}}
+	a.i.pointer.mx.Lock()
+	state.x, state.y = a.i.pointer.x, a.i.pointer.y
+	a.i.pointer.mx.Unlock()
+	for _, step := range a.steps {
+		if err := step(state); err != nil {
+			a.release(state)
+			return err
+		}
+	}
+	return nil
+}
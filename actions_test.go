@@ -0,0 +1,62 @@
+package control
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ecwid/control/protocol/input"
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+func TestActionsReleaseOnFailure(t *testing.T) {
+	var failed = cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(`{"id":3,"sessionId":"` + testSessionID + `","error":{"code":-32000,"message":"failed"}}`)}
+	s, srv, log := testSession(t,
+		call(1, "Input.dispatchKeyEvent", ""),
+		reply(1, `{}`),
+		call(2, "Input.dispatchKeyEvent", ""),
+		reply(2, `{}`),
+		call(3, "Input.dispatchMouseEvent", ""),
+		failed,
+		call(4, "Input.dispatchMouseEvent", ""),
+		reply(4, `{}`),
+		call(5, "Input.dispatchKeyEvent", ""),
+		reply(5, `{}`),
+		call(6, "Input.dispatchKeyEvent", ""),
+		reply(6, `{}`),
+	)
+	var err = s.Input.Actions().
+		KeyDown(KeyShift).
+		KeyDown(keyDefinitions['a']).
+		MouseDown(MouseLeft).
+		Perform()
+	if err == nil {
+		t.Fatal("expected error of failed step")
+	}
+	played(t, srv)
+	var want = []struct {
+		Type string
+		Key  string
+	}{
+		{"rawKeyDown", "Shift"},
+		{"keyDown", "A"},
+		{"keyUp", "A"}, // ordinary keys are released before modifiers
+		{"keyUp", "Shift"},
+	}
+	var sent = log.sent(t, "Input.dispatchKeyEvent")
+	if len(sent) != len(want) {
+		t.Fatalf("expected %d key events, got %d", len(want), len(sent))
+	}
+	for n, params := range sent {
+		var v input.DispatchKeyEventArgs
+		if err = json.Unmarshal(params, &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.Type != want[n].Type || v.Key != want[n].Key {
+			t.Errorf("key event %d: expected %s %s, got %s %s", n, want[n].Type, want[n].Key, v.Type, v.Key)
+		}
+	}
+	if released := log.sent(t, "Input.dispatchMouseEvent"); len(released) != 2 {
+		t.Errorf("expected mouse button to be released, got %d mouse events", len(released))
+	}
+}
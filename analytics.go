@@ -0,0 +1,137 @@
+package control
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	AnalyticsBeacon    = "beacon"    // navigator.sendBeacon
+	AnalyticsPing      = "ping"      // <a ping>
+	AnalyticsKeepalive = "keepalive" // fetch(..., {keepalive: true})
+)
+
+const exposeKeepalive = "__control_keepalive"
+
+// AnalyticsCall fire-and-forget request captured by AnalyticsRecorder
+type AnalyticsCall struct {
+	Kind   string
+	URL    string
+	Method string
+	Query  url.Values
+	Body   string
+	Form   url.Values  // body parsed as application/x-www-form-urlencoded
+	JSON   interface{} // body parsed as JSON
+	Time   time.Time
+}
+
+func newAnalyticsCall(kind, rawURL, method, body string) AnalyticsCall {
+	var call = AnalyticsCall{Kind: kind, URL: rawURL, Method: method, Body: body, Time: time.Now()}
+	if u, err := url.Parse(rawURL); err == nil {
+		call.Query = u.Query()
+	}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &call.JSON); err != nil {
+			call.JSON = nil
+			if form, err1 := url.ParseQuery(body); err1 == nil {
+				call.Form = form
+			}
+		}
+	}
+	return call
+}
+
+// AnalyticsRecorder captures beacons, pings and keepalive fetches of the page
+type AnalyticsRecorder struct {
+	mx     sync.Mutex
+	calls  []AnalyticsCall
+	cancel []func()
+}
+
+func (r *AnalyticsRecorder) add(call AnalyticsCall) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns all captured calls
+func (r *AnalyticsRecorder) Calls() []AnalyticsCall {
+	return r.Find(func(AnalyticsCall) bool { return true })
+}
+
+// Find returns captured calls matched by predicate
+func (r *AnalyticsRecorder) Find(predicate func(AnalyticsCall) bool) []AnalyticsCall {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	var calls []AnalyticsCall
+	for _, c := range r.calls {
+		if predicate(c) {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Stop stop capturing
+func (r *AnalyticsRecorder) Stop() {
+	for _, c := range r.cancel {
+		c()
+	}
+	r.cancel = nil
+}
+
+// RecordAnalytics start capturing of analytics calls. Keepalive fetches are captured in documents created after the call
+func (s Session) RecordAnalytics() (*AnalyticsRecorder, error) {
+	var recorder = &AnalyticsRecorder{}
+	recorder.cancel = append(recorder.cancel, s.Subscribe("Network.requestWillBeSent", func(value transport.Event) {
+		var sent = network.RequestWillBeSent{}
+		if err := json.Unmarshal(value.Params, &sent); err != nil || sent.Type != "Ping" {
+			return
+		}
+		var kind = AnalyticsBeacon
+		if sent.Request.Headers != nil {
+			headers, _ := (*sent.Request.Headers).(map[string]interface{})
+			for name := range headers {
+				if strings.EqualFold(name, "Ping-To") {
+					kind = AnalyticsPing
+				}
+			}
+		}
+		if sent.Request.HasPostData && sent.Request.PostData == "" {
+			go func() {
+				body, _ := s.Network.GetRequestPostData(sent.RequestId)
+				recorder.add(newAnalyticsCall(kind, sent.Request.Url, sent.Request.Method, body))
+			}()
+			return
+		}
+		recorder.add(newAnalyticsCall(kind, sent.Request.Url, sent.Request.Method, sent.Request.PostData))
+	}))
+	cancel, err := s.ExposeFunction(exposeKeepalive, func(args []json.RawMessage) (interface{}, error) {
+		var values = make([]string, 3) // url, method, body
+		for i := 0; i < len(args) && i < len(values); i++ {
+			_ = json.Unmarshal(args[i], &values[i])
+		}
+		recorder.add(newAnalyticsCall(AnalyticsKeepalive, values[0], values[1], values[2]))
+		return nil, nil
+	})
+	if err != nil {
+		recorder.Stop()
+		return nil, err
+	}
+	recorder.cancel = append(recorder.cancel, cancel)
+	identifier, err := s.AddScriptToEvaluateOnNewDocument(functionWrapKeepalive)
+	if err != nil {
+		recorder.Stop()
+		return nil, err
+	}
+	recorder.cancel = append(recorder.cancel, func() {
+		_ = s.RemoveScriptToEvaluateOnNewDocument(identifier)
+	})
+	return recorder, nil
+}
@@ -0,0 +1,160 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ecwid/control/protocol/animation"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+// Animations control of CSS and web animations of the page, animations are tracked after Enable
+type Animations struct {
+	s *Session
+}
+
+type animations struct {
+	mx      sync.Mutex
+	started map[string]*animation.Animation
+	cancel  func()
+}
+
+func newAnimations() *animations {
+	return &animations{started: map[string]*animation.Animation{}}
+}
+
+// Enable start tracking of animations
+func (a Animations) Enable() error {
+	var tracked = a.s.animations
+	tracked.mx.Lock()
+	if tracked.cancel == nil {
+		tracked.cancel = a.s.Subscribe("*", func(e transport.Event) {
+			switch e.Method {
+			case "Animation.animationStarted":
+				var v = animation.AnimationStarted{}
+				if err := json.Unmarshal(e.Params, &v); err == nil && v.Animation != nil {
+					tracked.mx.Lock()
+					tracked.started[v.Animation.Id] = v.Animation
+					tracked.mx.Unlock()
+				}
+			case "Animation.animationCanceled":
+				var v = animation.AnimationCanceled{}
+				if err := json.Unmarshal(e.Params, &v); err == nil {
+					tracked.mx.Lock()
+					delete(tracked.started, v.Id)
+					tracked.mx.Unlock()
+				}
+			case "Page.frameNavigated":
+				// animations of the previous document are unknown to the browser after navigation
+				var v = page.FrameNavigated{}
+				if err := json.Unmarshal(e.Params, &v); err == nil && v.Frame != nil && v.Frame.ParentId == "" {
+					tracked.mx.Lock()
+					tracked.started = map[string]*animation.Animation{}
+					tracked.mx.Unlock()
+				}
+			}
+		})
+	}
+	tracked.mx.Unlock()
+	return animation.Enable(a.s)
+}
+
+// Disable stop tracking of animations and forget tracked ones
+func (a Animations) Disable() error {
+	var tracked = a.s.animations
+	tracked.mx.Lock()
+	if tracked.cancel != nil {
+		tracked.cancel()
+		tracked.cancel = nil
+	}
+	tracked.started = map[string]*animation.Animation{}
+	tracked.mx.Unlock()
+	return animation.Disable(a.s)
+}
+
+// List returns tracked animations
+func (a Animations) List() []*animation.Animation {
+	a.s.animations.mx.Lock()
+	defer a.s.animations.mx.Unlock()
+	var list = make([]*animation.Animation, 0, len(a.s.animations.started))
+	for _, v := range a.s.animations.started {
+		list = append(list, v)
+	}
+	return list
+}
+
+// alive tracked animations still known to the browser, finished and released ones are forgotten
+func (a Animations) alive() []*animation.Animation {
+	var list []*animation.Animation
+	for _, v := range a.List() {
+		if _, err := animation.GetCurrentTime(a.s, animation.GetCurrentTimeArgs{Id: v.Id}); err != nil {
+			a.s.animations.mx.Lock()
+			delete(a.s.animations.started, v.Id)
+			a.s.animations.mx.Unlock()
+			continue
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+func (a Animations) ids() []string {
+	var list = a.alive()
+	var ids = make([]string, len(list))
+	for i, v := range list {
+		ids[i] = v.Id
+	}
+	return ids
+}
+
+// SetPlaybackRate set playback rate of all animations of the page, 0 freezes them, 10 plays them 10 times faster
+func (a Animations) SetPlaybackRate(rate float64) error {
+	return animation.SetPlaybackRate(a.s, animation.SetPlaybackRateArgs{PlaybackRate: rate})
+}
+
+// GetPlaybackRate ...
+func (a Animations) GetPlaybackRate() (float64, error) {
+	val, err := animation.GetPlaybackRate(a.s)
+	if err != nil {
+		return 0, err
+	}
+	return val.PlaybackRate, nil
+}
+
+// SetPaused pause or resume tracked animations
+func (a Animations) SetPaused(paused bool) error {
+	var ids = a.ids()
+	if len(ids) == 0 {
+		return nil
+	}
+	return animation.SetPaused(a.s, animation.SetPausedArgs{Animations: ids, Paused: paused})
+}
+
+// Seek set current time (ms) of tracked animations
+func (a Animations) Seek(currentTime float64) error {
+	var ids = a.ids()
+	if len(ids) == 0 {
+		return nil
+	}
+	return animation.SeekAnimations(a.s, animation.SeekAnimationsArgs{Animations: ids, CurrentTime: currentTime})
+}
+
+// Finish seek tracked animations to their end state, infinite animations are seeked to the end of the first iteration
+func (a Animations) Finish() error {
+	for _, v := range a.alive() {
+		if v.Source == nil {
+			continue
+		}
+		var iterations = v.Source.Iterations
+		if iterations <= 0 || iterations > 1e6 {
+			iterations = 1
+		}
+		var end = v.Source.Delay + v.Source.Duration*iterations + v.Source.EndDelay
+		err := animation.SeekAnimations(a.s, animation.SeekAnimationsArgs{Animations: []string{v.Id}, CurrentTime: end})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AssertionError failed assertion, Screenshot (png) and URL of the page are captured in soft-assertion mode
+type AssertionError struct {
+	Message    string
+	Err        error
+	URL        string
+	Screenshot []byte
+	Time       time.Time
+}
+
+func (e AssertionError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e AssertionError) Unwrap() error {
+	return e.Err
+}
+
+// SoftAssertionError all assertion failures recorded in soft-assertion mode
+type SoftAssertionError struct {
+	Failures []AssertionError
+}
+
+func (e SoftAssertionError) Error() string {
+	var lines = make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		lines[i] = fmt.Sprintf("%d) %s (at %s)", i+1, f.Error(), f.URL)
+	}
+	return fmt.Sprintf("%d assertion(s) failed:\n%s", len(e.Failures), strings.Join(lines, "\n"))
+}
+
+type softAssertions struct {
+	mx       sync.Mutex
+	enabled  bool
+	failures []AssertionError
+}
+
+// SetSoftAssertions in soft-assertion mode failed assertions are recorded with screenshot and
+// don't return error, use AssertAll to report them at the end of the scenario
+func (s Session) SetSoftAssertions(enabled bool) {
+	s.soft.mx.Lock()
+	defer s.soft.mx.Unlock()
+	s.soft.enabled = enabled
+}
+
+// Assert fail assertion if err is not nil (or there are uncaught page exceptions in fail-fast mode):
+// in soft-assertion mode the failure is recorded and nil is returned, otherwise AssertionError is returned
+func (s Session) Assert(err error, message string) error {
+	if err == nil && s.pageErrors.failFastC() != nil {
+		if list := s.DrainPageErrors(); len(list) > 0 {
+			err = list[0]
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	var failure = AssertionError{Message: message, Err: err, Time: time.Now()}
+	s.soft.mx.Lock()
+	var soft = s.soft.enabled
+	s.soft.mx.Unlock()
+	if !soft {
+		return failure
+	}
+	if entry, err := s.Page().GetNavigationEntry(); err == nil {
+		failure.URL = entry.Url
+	}
+	failure.Screenshot, _ = s.CaptureScreenshot("png", 0, nil, true, false)
+	s.soft.mx.Lock()
+	s.soft.failures = append(s.soft.failures, failure)
+	s.soft.mx.Unlock()
+	return nil
+}
+
+// AssertTrue fail assertion with formatted message if ok is false
+func (s Session) AssertTrue(ok bool, format string, args ...interface{}) error {
+	if ok {
+		return nil
+	}
+	return s.Assert(fmt.Errorf(format, args...), "")
+}
+
+// AssertExists fail assertion if there is no element matching selector
+func (s Session) AssertExists(selector string) error {
+	return s.AssertTrue(s.Page().IsExist(selector), "no such element `%s`", selector)
+}
+
+// AssertText fail assertion if text of the element is not equal to expected
+func (s Session) AssertText(selector, expected string) error {
+	el, err := s.Page().QuerySelector(selector)
+	if err != nil {
+		return s.Assert(err, "")
+	}
+	text, err := el.GetText()
+	if err != nil {
+		return s.Assert(err, "")
+	}
+	return s.AssertTrue(text == expected, "text of `%s` is `%s`, expected `%s`", selector, text, expected)
+}
+
+// AssertionFailures returns failures recorded in soft-assertion mode
+func (s Session) AssertionFailures() []AssertionError {
+	s.soft.mx.Lock()
+	defer s.soft.mx.Unlock()
+	return append([]AssertionError{}, s.soft.failures...)
+}
+
+// AssertAll returns SoftAssertionError with all recorded failures (or nil) and resets them
+func (s Session) AssertAll() error {
+	s.soft.mx.Lock()
+	defer s.soft.mx.Unlock()
+	if len(s.soft.failures) == 0 {
+		return nil
+	}
+	var err = SoftAssertionError{Failures: s.soft.failures}
+	s.soft.failures = nil
+	return err
+}
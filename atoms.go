@@ -22,5 +22,18 @@ const (
 	functionSelect               = `function(a){const b=Array.from(this.options);this.value=void 0;for(const c of b)if(c.selected=a.includes(c.value),c.selected&&!this.multiple)break}`
 	functionGetSelectedValues    = `function(){return Array.from(this.options).filter(a=>a.selected).map(a=>a.value)}`
 	functionGetSelectedInnerText = `function(){return Array.from(this.options).filter(a=>a.selected).map(a=>a.innerText)}`
-	functionDOMIdle              = `var d=function(e,t,n){var u,r=null;return function(){var i=this,o=arguments,s=n&&!r;return clearTimeout(r),r=setTimeout(function(){r=null,n||(u=e.apply(i,o))},t),s&&(u=e.apply(i,o)),u}};new Promise((e,t)=>{var n=d(function(){e()},%d);new MutationObserver(n).observe(document,{attributes:!0,childList:!0,subtree:!0}),n(),setTimeout(()=>t("timeout"),%d)});`
+	functionExpose               = `function(n){if(window[n])return;let c=new Map,q=0;window[n]=function(...a){return new Promise((r,j)=>{let s=++q;c.set(s,{r,j});window._on_expose(JSON.stringify({name:n,seq:s,args:a}))})};window[n].__cb=c}`
+	functionExposeDeliver        = `function(n,s,v,e){let c=window[n].__cb,p=c.get(s);c.delete(s);if(p)e?p.j(new Error(e)):p.r(v)}`
+	functionWaitFor              = `new Promise((r,j)=>{let d=0,p=()=>(%s),t=setTimeout(()=>{d=1;j("timeout")},%d),n=f=>%s,f=()=>{if(d)return;let v;try{v=p()}catch(e){clearTimeout(t);return j(e)}if(v){clearTimeout(t);r(v)}else n(f)};f()})`
+	functionFindByText           = `function(t,x){let n=s=>(s||"").replace(/\s+/g," ").trim(),w=document.createTreeWalker(this,NodeFilter.SHOW_ELEMENT),r=[],e;while(e=w.nextNode()){let v=n(e.innerText);if(x?v===t:v.includes(t))r.push(e)}return r.filter(a=>!r.some(b=>b!==a&&a.contains(b)))[0]||null}`
+	functionNearMissText         = `function(t,m){let n=s=>(s||"").replace(/\s+/g," ").trim(),l=n(t).toLowerCase(),w=document.createTreeWalker(this,NodeFilter.SHOW_ELEMENT),r=new Set,e;while((e=w.nextNode())&&r.size<m){if(e.children.length)continue;let v=n(e.innerText),c=v.toLowerCase();if(v&&(c.includes(l)||l.includes(c)||c.split(" ").some(a=>a.length>2&&l.includes(a))))r.add(v.substr(0,80))}return Array.from(r)}`
+	functionScrollOffset         = `function(o,a){if(a)for(const e of document.querySelectorAll("body *")){let s=getComputedStyle(e);if(s.position!=="fixed"&&s.position!=="sticky")continue;let r=e.getBoundingClientRect();if(r.top<=1&&r.bottom>0&&r.width>innerWidth/2&&!e.contains(this))o=Math.max(o,r.bottom)}let t=this.getBoundingClientRect().top;if(t<o)window.scrollBy(0,t-o)}`
+	functionWrapKeepalive        = `(()=>{const f=window.fetch;window.fetch=function(i,o){try{if(o&&o.keepalive){let u=typeof i==="string"?i:i.url;__control_keepalive(new URL(u,location.href).href,(o.method||"GET").toUpperCase(),typeof o.body==="string"?o.body:o.body instanceof URLSearchParams?o.body.toString():"")}}catch(e){}return f.apply(this,arguments)}})()`
+	functionOriginTrialMeta      = `((t)=>{let a=()=>{for(const k of t){let m=document.createElement("meta");m.httpEquiv="origin-trial";m.content=k;document.head.prepend(m)}};if(document.head)return a();new MutationObserver((_,o)=>{if(document.head){o.disconnect();a()}}).observe(document,{childList:!0,subtree:!0})})(%s)`
+	functionObserveMutation      = `function(i,o){let m=new MutationObserver(r=>{for(const v of r){if(v.type==="childList"&&[...v.addedNodes,...v.removedNodes].every(n=>n.nodeType===1&&n.hasAttribute("data-control-cursor")))continue;m.disconnect();_on_mutation(JSON.stringify({id:i,type:v.type,target:v.target.nodeName,added:v.addedNodes.length,removed:v.removedNodes.length,attribute:v.attributeName||"",oldValue:v.oldValue||""}));return}});m.observe(this,o);return m}`
+	functionSaveScroll           = `function(){let r=[],d=document.scrollingElement||document.documentElement;for(let e=this;e;e=e.parentElement)if(e===d||e.scrollHeight>e.clientHeight||e.scrollWidth>e.clientWidth)r.push([e,e.scrollLeft,e.scrollTop]);if(!r.some(v=>v[0]===d))r.push([d,d.scrollLeft,d.scrollTop]);return r}`
+	functionRestoreScroll        = `function(){for(const[e,l,t]of this)e.scrollTo({left:l,top:t,behavior:"instant"})}`
+	functionDOMIdle              = `var d=function(e,t,n){var u,r=null;return function(){var i=this,o=arguments,s=n&&!r;return clearTimeout(r),r=setTimeout(function(){r=null,n||(u=e.apply(i,o))},t),s&&(u=e.apply(i,o)),u}};new Promise((e,t)=>{var n=d(function(){e()},%d);new MutationObserver(r=>{for(const v of r)if(v.type!=="childList"||![...v.addedNodes,...v.removedNodes].every(n=>n.nodeType===1&&n.hasAttribute("data-control-cursor")))return n()}).observe(document,{attributes:!0,childList:!0,subtree:!0}),n(),setTimeout(()=>t("timeout"),%d)});`
+	functionCursorOverlay        = `(()=>{if(window.__control_cursor)return;let h,s,c,o="position:fixed;pointer-events:none;border-radius:50%;",g=()=>{if(!h||!h.isConnected){h=document.createElement("div");h.setAttribute("data-control-cursor","");h.style.cssText="position:fixed;left:0;top:0;width:0;height:0;z-index:2147483647;pointer-events:none";s=h.attachShadow({mode:"closed"});c=document.createElement("div");c.style.cssText=o+"width:14px;height:14px;margin:-8px 0 0 -8px;background:rgba(255,40,40,.7);border:1px solid #fff";s.appendChild(c);(document.body||document.documentElement).appendChild(h)}},m=(x,y)=>{g();c.style.left=x+"px";c.style.top=y+"px"},r=(x,y)=>{m(x,y);let d=document.createElement("div");d.style.cssText=o+"width:40px;height:40px;margin:-22px 0 0 -22px;border:2px solid rgba(255,40,40,.9);left:"+x+"px;top:"+y+"px";s.appendChild(d);d.animate([{transform:"scale(.2)",opacity:1},{transform:"scale(1)",opacity:0}],{duration:500}).onfinish=()=>d.remove()},l={mousemove:e=>m(e.clientX,e.clientY),mousedown:e=>r(e.clientX,e.clientY),touchstart:e=>{for(const t of e.changedTouches)r(t.clientX,t.clientY)},touchmove:e=>{for(const t of e.changedTouches)m(t.clientX,t.clientY)}};for(const k in l)window.addEventListener(k,l[k],{capture:!0,passive:!0});window.__control_cursor=()=>{for(const k in l)window.removeEventListener(k,l[k],{capture:!0});h&&h.remove();delete window.__control_cursor}})()`
+	functionCursorOverlayRemove  = `window.__control_cursor&&window.__control_cursor()`
 )
@@ -0,0 +1,128 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+const (
+	authResponseDefault     = "Default"
+	authResponseCancel      = "CancelAuth"
+	authResponseCredentials = "ProvideCredentials"
+	authSourceProxy         = "Proxy"
+)
+
+// authRequired answer the challenge with credentials once, repeated challenge of the request means
+// wrong credentials and it's canceled so the page gets 401 instead of hanging on the prompt
+func (r *routes) authRequired(s *Session, v fetch.AuthRequired) {
+	r.mx.Lock()
+	var (
+		response    = &fetch.AuthChallengeResponse{Response: authResponseDefault}
+		credentials = r.credentials
+	)
+	if c := v.AuthChallenge; c != nil && c.Source != authSourceProxy && r.scoped[c.Origin] != nil {
+		credentials = r.scoped[c.Origin]
+	}
+	switch {
+	case credentials == nil:
+	case r.challenged[v.RequestId]:
+		response = &fetch.AuthChallengeResponse{Response: authResponseCancel}
+		delete(r.challenged, v.RequestId)
+	default:
+		response = credentials
+		if r.challenged == nil {
+			r.challenged = map[fetch.RequestId]bool{}
+		}
+		r.challenged[v.RequestId] = true
+	}
+	r.mx.Unlock()
+	go func() {
+		_ = fetch.ContinueWithAuth(s, fetch.ContinueWithAuthArgs{RequestId: v.RequestId, AuthChallengeResponse: response})
+	}()
+}
+
+// intercept remember the interception of the network request, interception of the previous hop (redirect)
+// is done and it's forgotten. Lock of routes is required
+func (r *routes) intercept(networkID network.RequestId, id fetch.RequestId) {
+	if networkID == "" {
+		return
+	}
+	if r.intercepted == nil {
+		r.intercepted = map[network.RequestId]fetch.RequestId{}
+	}
+	if prev, ok := r.intercepted[networkID]; ok && prev != id {
+		delete(r.challenged, prev)
+	}
+	r.intercepted[networkID] = id
+}
+
+// finished forget the interception and the challenge of the request that has finished or failed loading
+func (r *routes) finished(networkID network.RequestId) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if id, ok := r.intercepted[networkID]; ok {
+		delete(r.challenged, id)
+		delete(r.intercepted, networkID)
+	}
+}
+
+// Authenticate answer HTTP basic/digest and proxy auth challenges of the page with credentials
+func (s Session) Authenticate(username, password string) error {
+	var credentials = &fetch.AuthChallengeResponse{
+		Response: authResponseCredentials,
+		Username: username,
+		Password: password,
+	}
+	s.routes.mx.Lock()
+	var prev = s.routes.credentials
+	s.routes.credentials = credentials
+	s.routes.challenged = map[fetch.RequestId]bool{}
+	s.routes.mx.Unlock()
+	if err := s.routes.enable(s); err != nil {
+		s.routes.mx.Lock()
+		if s.routes.credentials == credentials {
+			s.routes.credentials = prev
+		}
+		s.routes.mx.Unlock()
+		return err
+	}
+	return nil
+}
+
+// ClearAuthentication stop answering auth challenges, the browser handles them by default.
+// Credentials of the environment origin are kept
+func (s Session) ClearAuthentication() error {
+	s.routes.mx.Lock()
+	s.routes.credentials = nil
+	s.routes.mx.Unlock()
+	return s.routes.enable(s)
+}
+
+// authenticateOrigin answer server auth challenges of the origin (scheme://host[:port]) with credentials
+func (s Session) authenticateOrigin(origin, username, password string) error {
+	var credentials = &fetch.AuthChallengeResponse{
+		Response: authResponseCredentials,
+		Username: username,
+		Password: password,
+	}
+	s.routes.mx.Lock()
+	if s.routes.scoped == nil {
+		s.routes.scoped = map[string]*fetch.AuthChallengeResponse{}
+	}
+	var prev, existed = s.routes.scoped[origin]
+	s.routes.scoped[origin] = credentials
+	s.routes.mx.Unlock()
+	if err := s.routes.enable(s); err != nil {
+		s.routes.mx.Lock()
+		if s.routes.scoped[origin] == credentials {
+			if existed {
+				s.routes.scoped[origin] = prev
+			} else {
+				delete(s.routes.scoped, origin)
+			}
+		}
+		s.routes.mx.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package control
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	NavigationTypeNavigation              page.NavigationType = "Navigation"
+	NavigationTypeBackForwardCacheRestore page.NavigationType = "BackForwardCacheRestore"
+)
+
+// BackForwardCacheResult result of history navigation of the main frame
+type BackForwardCacheResult struct {
+	Restored bool // page was served from back-forward cache
+	URL      string
+	// NotRestored reasons why the page was not restored from bfcache
+	NotRestored []*page.BackForwardCacheNotRestoredExplanation
+}
+
+// OnBackForwardCacheNotUsed subscribe to failed bfcache history navigations of the main frame
+func (s Session) OnBackForwardCacheNotUsed(handler func(page.BackForwardCacheNotUsed)) (cancel func()) {
+	return s.Subscribe("Page.backForwardCacheNotUsed", func(e transport.Event) {
+		var v = page.BackForwardCacheNotUsed{}
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			handler(v)
+		}
+	})
+}
+
+// ExpectBackForwardCache performs history navigation action (e.g. NavigateHistory(-1)) and reports
+// if the page was restored from bfcache. Page that was not restored is awaited until load event
+func (s Session) ExpectBackForwardCache(action func() error, timeout time.Duration) (*BackForwardCacheResult, error) {
+	var (
+		main   = common.FrameId(s.tid)
+		result = &BackForwardCacheResult{}
+	)
+	future := s.Observe("*", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		switch value.Method {
+		case "Page.backForwardCacheNotUsed":
+			var v = page.BackForwardCacheNotUsed{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.FrameId == main {
+				result.NotRestored = append(result.NotRestored, v.NotRestoredExplanations...)
+			}
+		case "Page.frameNavigated":
+			var v = page.FrameNavigated{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.Frame.Id != main {
+				return
+			}
+			result.URL = v.Frame.Url
+			if v.Type == NavigationTypeBackForwardCacheRestore {
+				result.Restored = true
+				resolve(*result)
+			}
+		case "Page.loadEventFired":
+			if result.URL != "" {
+				resolve(*result)
+			}
+		}
+	})
+	defer future.Cancel()
+	if err := action(); err != nil {
+		return nil, err
+	}
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	var r = val.(BackForwardCacheResult)
+	return &r, nil
+}
+
+// AssertBackForwardCache performs history navigation action and fails assertion with BackForwardCacheError
+// if the page was (restored = false) or wasn't (restored = true) served from bfcache
+func (s Session) AssertBackForwardCache(action func() error, restored bool, timeout time.Duration) error {
+	result, err := s.ExpectBackForwardCache(action, timeout)
+	if err != nil {
+		return err
+	}
+	if result.Restored != restored {
+		return s.Assert(BackForwardCacheError{Expected: restored, Result: *result}, "")
+	}
+	return nil
+}
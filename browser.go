@@ -2,26 +2,81 @@ package control
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/ecwid/control/protocol/browser"
 	"github.com/ecwid/control/protocol/network"
 	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/protocol/preload"
 	"github.com/ecwid/control/protocol/runtime"
 	"github.com/ecwid/control/protocol/target"
 	"github.com/ecwid/control/transport"
 )
 
+// BrowserContext must be created with New, zero value or literal (e.g. &BrowserContext{Client: c}) has no state
+// to track sessions and can't attach targets (ErrNotInitialized)
 type BrowserContext struct {
 	Client *transport.Client
+	// RunID correlation id of the current run, it's reported by Describe, RunSummary and HAR,
+	// and sent with every request of the sessions in RunIDHeader (if not empty). Use SetRunID to write it
+	// to every line of Client.Logger too
+	RunID       string
+	RunIDHeader string
+	// Constrained tuning for chrome-headless-shell and --single-process environments with tight memory:
+	// target discovering is not enabled for sessions and network buffers are reduced
+	Constrained bool
+	// Summary if not nil then JSON RunSummary is written to it on Close
+	Summary io.Writer
+	// MaxPostDataSize longest post body (in bytes) included in requestWillBeSent notification of new sessions,
+	// DefaultMaxPostDataSize if 0. Longer bodies are retrieved by Request.GetPostData
+	MaxPostDataSize int
+	sessions        *sync.Map
+	defaults        *sync.Map // EmulationDefaults by browser context id
+	downloads       *sync.Map // downloadConfig by browser context id or target id
+	// environments registry of target environments, see ResolveURL
+	environments *environments
+	history      *actionHistory
+	stats        *runStats
+	focus        *focusCoordinator
+	slowMo       *int64 // time.Duration of SetSlowMo
 }
 
+const (
+	constrainedTotalBufferSize    = 1024 * 1024
+	constrainedResourceBufferSize = 256 * 1024
+	DefaultMaxPostDataSize        = 2 * 1024
+)
+
 func New(client *transport.Client) *BrowserContext {
-	return &BrowserContext{Client: client}
+	return &BrowserContext{Client: client, sessions: &sync.Map{}, defaults: &sync.Map{}, downloads: &sync.Map{}, environments: newEnvironments(), history: newActionHistory(), stats: newRunStats(), focus: newFocusCoordinator(), slowMo: new(int64)}
+}
+
+// SetRunID set RunID of the browser context and label every line of Client.Logger with it
+func (b *BrowserContext) SetRunID(id string) {
+	b.RunID = id
+	b.Client.Logger.SetRunID(id)
+}
+
+// networkArgs arguments of Network.enable, maxPostDataSize of 0 means DefaultMaxPostDataSize
+func (b BrowserContext) networkArgs(maxPostDataSize int) network.EnableArgs {
+	if maxPostDataSize <= 0 {
+		maxPostDataSize = DefaultMaxPostDataSize
+	}
+	var args = network.EnableArgs{MaxPostDataSize: maxPostDataSize}
+	if b.Constrained {
+		args.MaxTotalBufferSize = constrainedTotalBufferSize
+		args.MaxResourceBufferSize = constrainedResourceBufferSize
+	}
+	return args
 }
 
 func (b BrowserContext) Call(method string, send, recv interface{}) error {
-	return b.Client.Call("", method, send, recv)
+	err := b.Client.Call("", method, send, recv)
+	if isMethodNotFound(err) {
+		return DomainUnavailableError{Method: method}
+	}
+	return err
 }
 
 func (b BrowserContext) Crash() error {
@@ -29,6 +84,10 @@ func (b BrowserContext) Crash() error {
 }
 
 func (b BrowserContext) Close() error {
+	if err := b.writeSummary(); err != nil {
+		_ = b.Client.Close()
+		return err
+	}
 	return b.Client.Close()
 }
 
@@ -36,9 +95,9 @@ func (b BrowserContext) SetDiscoverTargets(discover bool) error {
 	return target.SetDiscoverTargets(b, target.SetDiscoverTargetsArgs{Discover: discover})
 }
 
-func (b *BrowserContext) runSession(targetID target.TargetID, sessionID target.SessionID) (session *Session, err error) {
+func (b *BrowserContext) newSession(targetID target.TargetID, sessionID target.SessionID) *Session {
 	var uid uint64 = 0
-	session = &Session{
+	session := &Session{
 		guid:       &uid,
 		id:         sessionID,
 		tid:        targetID,
@@ -46,15 +105,62 @@ func (b *BrowserContext) runSession(targetID target.TargetID, sessionID target.S
 		eventPool:  make(chan transport.Event, 1000),
 		publisher:  transport.NewPublisher(),
 		executions: &sync.Map{},
+		stats:      newSessionStats(),
+		scripts:    &sync.Map{},
+		missing:    &sync.Map{},
+		worlds:     &sync.Map{},
+
+		lifecycleState: newStateMachine(),
+		loadStates:     newLoadStates(),
+		actions:        &sync.Map{},
+		oopifs:         &sync.Map{},
+		oopifHooks:     &sync.Map{},
+		children:       &sync.Map{},
+		workers:        &sync.Map{},
+		workerHooks:    &sync.Map{},
+		decodeHooks:    &sync.Map{},
+		scrollOffset:   &scrollOffset{},
+		ocr:            &ocrHolder{},
+		dialogs:        newDialogs(),
+		routes:         &routes{},
+		prerender:      newPrerenders(),
+		seeds:          newSeeds(),
+		serviceWorkers: newServiceWorkers(),
+		soft:           &softAssertions{},
+		pageErrors:     newPageErrors(),
+		clock:          &sessionClock{},
+		checkpoints:    newCheckpoints(),
+		animations:     newAnimations(),
+		eventSources:   &sync.Map{},
+		extraHeaders:   &extraHeaders{values: map[string]string{}},
+		fromCache:      &sync.Map{},
+		slowMo:         newSlowMo(),
 	}
 	session.context, session.exit = context.WithCancel(context.TODO())
-	session.Input = Input{s: session, mx: &sync.Mutex{}}
+	session.Input = Input{s: session, mx: &sync.Mutex{}, pointer: &pointer{}}
 	session.Network = Network{s: session}
 	session.Emulation = Emulation{s: session}
+	session.ServiceWorkers = ServiceWorkers{s: session}
+	session.Animations = Animations{s: session}
 
+	session.closed = b.stats.sessionCreated()
 	go session.lifecycle()
 	b.Client.Register(session)
+	b.sessions.Store(targetID, session)
+	return session
+}
 
+func (b *BrowserContext) runSession(targetID target.TargetID, sessionID target.SessionID) (session *Session, err error) {
+	var created = b.newSession(targetID, sessionID)
+	defer func() {
+		if err != nil {
+			// session that failed to set up is released, lifecycle exits on detach
+			created.lifecycleState.set(StateClosed, err)
+			b.releaseSession(created)
+			_ = target.DetachFromTarget(b, target.DetachFromTargetArgs{SessionId: sessionID})
+		}
+	}()
+	session = created
 	if err = page.Enable(session); err != nil {
 		return nil, err
 	}
@@ -64,20 +170,48 @@ func (b *BrowserContext) runSession(targetID target.TargetID, sessionID target.S
 	if err = runtime.AddBinding(session, runtime.AddBindingArgs{Name: bindClick}); err != nil {
 		return nil, err
 	}
-	if err = page.SetLifecycleEventsEnabled(session, page.SetLifecycleEventsEnabledArgs{Enabled: true}); err != nil {
+	if err = session.optional(page.SetLifecycleEventsEnabled(session, page.SetLifecycleEventsEnabledArgs{Enabled: true})); err != nil {
 		return nil, err
 	}
-	if err = target.SetDiscoverTargets(session, target.SetDiscoverTargetsArgs{Discover: true}); err != nil {
+	if err = session.optional(preload.Enable(session)); err != nil {
 		return nil, err
 	}
-	// maxPostDataSize - Longest post body size (in bytes) that would be included in requestWillBeSent notification
-	if err = network.Enable(session, network.EnableArgs{MaxPostDataSize: 2 * 1024}); err != nil {
+	if err = session.optional(target.SetAutoAttach(session, autoAttachArgs)); err != nil {
 		return nil, err
 	}
+	if !b.Constrained {
+		if err = session.optional(target.SetDiscoverTargets(session, target.SetDiscoverTargetsArgs{Discover: true})); err != nil {
+			return nil, err
+		}
+	}
+	if err = session.optional(network.Enable(session, b.networkArgs(b.MaxPostDataSize))); err != nil {
+		return nil, err
+	}
+	if (b.RunID != "" && b.RunIDHeader != "" || b.Environment() != nil) && session.IsDomainAvailable("Network") {
+		if err = session.Network.SetExtraHTTPHeaders(nil); err != nil {
+			return nil, err
+		}
+	}
+	if env := b.Environment(); env != nil {
+		if err = session.optional(session.useEnvironment(env)); err != nil {
+			return nil, err
+		}
+	}
+	session.lifecycleState.set(StateReady, nil)
 	return
 }
 
+// releaseSession remove the session from attached sessions unless the target was attached again
+func (b *BrowserContext) releaseSession(s *Session) {
+	if val, ok := b.sessions.Load(s.tid); ok && val.(*Session) == s {
+		b.sessions.Delete(s.tid)
+	}
+}
+
 func (b *BrowserContext) AttachPageTarget(id target.TargetID) (*Session, error) {
+	if b.sessions == nil {
+		return nil, ErrNotInitialized
+	}
 	val, err := target.AttachToTarget(b, target.AttachToTargetArgs{
 		TargetId: id,
 		Flatten:  true,
@@ -89,11 +223,17 @@ func (b *BrowserContext) AttachPageTarget(id target.TargetID) (*Session, error)
 }
 
 func (b *BrowserContext) CreatePageTarget(url string) (*Session, error) {
+	if b.sessions == nil {
+		return nil, ErrNotInitialized
+	}
 	if url == "" {
 		url = Blank // headless chrome crash when url is empty
 	}
 	r, err := target.CreateTarget(b, target.CreateTargetArgs{Url: url})
 	if err != nil {
+		if _, ok := err.(DomainUnavailableError); ok || isNotSupported(err) {
+			return nil, ErrCreateTargetNotSupported
+		}
 		return nil, err
 	}
 	return b.AttachPageTarget(r.TargetId)
@@ -114,6 +254,14 @@ func (b BrowserContext) CloseTarget(id target.TargetID) (err error) {
 	return err
 }
 
+func (b BrowserContext) targetInfo(id target.TargetID) (*target.TargetInfo, error) {
+	val, err := target.GetTargetInfo(b, target.GetTargetInfoArgs{TargetId: id})
+	if err != nil {
+		return nil, err
+	}
+	return val.TargetInfo, nil
+}
+
 func (b BrowserContext) GetTargets() ([]*target.TargetInfo, error) {
 	val, err := target.GetTargets(b)
 	if err != nil {
@@ -0,0 +1,13 @@
+package control
+
+import "testing"
+
+func TestBrowserContextLiteral(t *testing.T) {
+	var b = &BrowserContext{}
+	if _, err := b.AttachPageTarget(testTargetID); err != ErrNotInitialized {
+		t.Fatalf("expected ErrNotInitialized, got %v", err)
+	}
+	if _, err := b.CreatePageTarget(""); err != ErrNotInitialized {
+		t.Fatalf("expected ErrNotInitialized, got %v", err)
+	}
+}
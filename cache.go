@@ -0,0 +1,143 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ecwid/control/protocol/cachestorage"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+// ClearBrowserCache clear HTTP cache of the browser
+func (s Session) ClearBrowserCache() error {
+	return network.ClearBrowserCache(s)
+}
+
+// ClearBrowserCookies delete all browser cookies
+func (s Session) ClearBrowserCookies() error {
+	return s.Network.ClearBrowserCookies()
+}
+
+// SetCacheDisabled toggles ignoring of HTTP cache for each request of the page
+func (n Network) SetCacheDisabled(disabled bool) error {
+	return network.SetCacheDisabled(n.s, network.SetCacheDisabledArgs{CacheDisabled: disabled})
+}
+
+// GetCacheStorage returns CacheStorage caches of the origin (empty origin means origin of the page)
+func (s Session) GetCacheStorage(origin string) ([]*cachestorage.Cache, error) {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	val, err := cachestorage.RequestCacheNames(s, cachestorage.RequestCacheNamesArgs{SecurityOrigin: origin})
+	if err != nil {
+		return nil, err
+	}
+	return val.Caches, nil
+}
+
+// DeleteCacheStorage delete CacheStorage caches of the origin by name, all caches are deleted if no names
+func (s Session) DeleteCacheStorage(origin string, names ...string) error {
+	caches, err := s.GetCacheStorage(origin)
+	if err != nil {
+		return err
+	}
+	var filter = map[string]bool{}
+	for _, name := range names {
+		filter[name] = true
+	}
+	for _, c := range caches {
+		if len(filter) > 0 && !filter[c.CacheName] {
+			continue
+		}
+		if err = cachestorage.DeleteCache(s, cachestorage.DeleteCacheArgs{CacheId: c.CacheId}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetCache starts page with cold cache: clears HTTP cache, cookies and CacheStorage of the page origin
+func (s Session) ResetCache() error {
+	if err := s.ClearBrowserCache(); err != nil {
+		return err
+	}
+	if err := s.ClearBrowserCookies(); err != nil {
+		return err
+	}
+	if origin, err := s.storageOrigin(""); err != nil || origin == "" || origin == "null" {
+		return err
+	}
+	return s.DeleteCacheStorage("")
+}
+
+// CacheSource where the response came from
+type CacheSource string
+
+const (
+	CacheSourceNetwork       CacheSource = "network"
+	CacheSourceMemory        CacheSource = "memory"
+	CacheSourceDisk          CacheSource = "disk"
+	CacheSourcePrefetch      CacheSource = "prefetch"
+	CacheSourceServiceWorker CacheSource = "serviceWorker"
+)
+
+// observeCacheSources tracks requests served from memory cache, such requests are reported by
+// Network.requestServedFromCache before its response
+func (s Session) observeCacheSources(e transport.Event) {
+	var v = struct {
+		RequestId network.RequestId `json:"requestId"`
+	}{}
+	switch e.Method {
+	case "Network.requestServedFromCache":
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			s.fromCache.Store(v.RequestId, true)
+		}
+	case "Network.loadingFinished", "Network.loadingFailed":
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			s.fromCache.Delete(v.RequestId)
+		}
+	}
+}
+
+func (s Session) servedFromMemoryCache(id network.RequestId) bool {
+	_, ok := s.fromCache.Load(id)
+	return ok
+}
+
+func (s Session) cacheSource(id network.RequestId, r *network.Response) CacheSource {
+	switch {
+	case r.FromServiceWorker:
+		return CacheSourceServiceWorker
+	case r.FromPrefetchCache:
+		return CacheSourcePrefetch
+	case s.servedFromMemoryCache(id):
+		return CacheSourceMemory
+	case r.FromDiskCache:
+		return CacheSourceDisk
+	}
+	return CacheSourceNetwork
+}
+
+// CollectCacheSources performs action (e.g. repeat navigation) and returns sources of responses received
+// during the action by url
+func (s Session) CollectCacheSources(action func() error) (map[string]CacheSource, error) {
+	var (
+		mx      sync.Mutex
+		sources = map[string]CacheSource{}
+	)
+	cancel := s.Network.OnResponse(func(r *Response) {
+		mx.Lock()
+		sources[r.URL] = r.Source
+		mx.Unlock()
+	})
+	err := action()
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	return sources, nil
+}
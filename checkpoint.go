@@ -0,0 +1,128 @@
+package control
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Checkpoint state of the page that can be restored to branch a flow from a known point
+type Checkpoint struct {
+	Name    string
+	URL     string
+	Storage *StorageState
+	ScrollX float64
+	ScrollY float64
+}
+
+type checkpoints struct {
+	mx    sync.Mutex
+	saved map[string]*Checkpoint
+}
+
+func newCheckpoints() *checkpoints {
+	return &checkpoints{saved: map[string]*Checkpoint{}}
+}
+
+// pageStorageState all browser cookies and web storage of the page origins
+func (s Session) pageStorageState() (*StorageState, error) {
+	cookies, err := s.Network.GetAllCookies()
+	if err != nil {
+		return nil, err
+	}
+	origins, err := s.origins()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		state = &StorageState{Cookies: cookies}
+		seen  = map[string]bool{}
+	)
+	for _, origin := range origins {
+		if seen[origin] {
+			continue
+		}
+		seen[origin] = true
+		var v = &OriginStorage{Origin: origin}
+		if v.LocalStorage, err = getStorageItems(&s, origin, true); err != nil {
+			return nil, err
+		}
+		if v.SessionStorage, err = getStorageItems(&s, origin, false); err != nil {
+			return nil, err
+		}
+		state.Origins = append(state.Origins, v)
+	}
+	return state, nil
+}
+
+// Checkpoint capture url, storage state (browser cookies and web storage of the page origins)
+// and scroll position of the page under the name, existing checkpoint with the same name is replaced
+func (s Session) Checkpoint(name string) (*Checkpoint, error) {
+	entry, err := s.Page().GetNavigationEntry()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := s.pageStorageState()
+	if err != nil {
+		return nil, err
+	}
+	scroll, err := s.Evaluate(`[window.scrollX,window.scrollY]`, false, true)
+	if err != nil {
+		return nil, err
+	}
+	var c = &Checkpoint{Name: name, URL: entry.Url, Storage: storage}
+	if xy, ok := scroll.([]interface{}); ok && len(xy) == 2 {
+		c.ScrollX, _ = xy[0].(float64)
+		c.ScrollY, _ = xy[1].(float64)
+	}
+	s.checkpoints.mx.Lock()
+	s.checkpoints.saved[name] = c
+	s.checkpoints.mx.Unlock()
+	return c, nil
+}
+
+// clearStorageState delete browser cookies and web storage of the checkpoint origins and the current page origins,
+// so state created after the checkpoint doesn't leak into the restored branch
+func (s Session) clearStorageState(c *Checkpoint) error {
+	if err := s.Network.ClearCookies(); err != nil {
+		return err
+	}
+	origins, err := s.origins()
+	if err != nil {
+		return err
+	}
+	for _, v := range c.Storage.Origins {
+		origins = append(origins, v.Origin)
+	}
+	var seen = map[string]bool{}
+	for _, origin := range origins {
+		if seen[origin] {
+			continue
+		}
+		seen[origin] = true
+		if err = s.ClearStorage(origin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore clear storage state, re-apply storage state of the checkpoint, navigate to its url and restore scroll position
+func (s Session) Restore(name string) error {
+	s.checkpoints.mx.Lock()
+	c, ok := s.checkpoints.saved[name]
+	s.checkpoints.mx.Unlock()
+	if !ok {
+		return NoCheckpointError{Name: name}
+	}
+	if err := s.clearStorageState(c); err != nil {
+		return err
+	}
+	if err := s.SetStorageState(c.Storage); err != nil {
+		return err
+	}
+	if err := s.Page().Navigate(c.URL, LifecycleLoad, s.browser.Client.Timeout); err != nil && err != ErrAlreadyNavigated {
+		return err
+	}
+	_, err := s.Page().Evaluate(fmt.Sprintf(`window.scrollTo(%f,%f)`, c.ScrollX, c.ScrollY), false, true)
+	return err
+}
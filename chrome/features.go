@@ -0,0 +1,43 @@
+package chrome
+
+import "strings"
+
+// Features Blink runtime features and Chrome features to toggle on launch.
+// Flags are applied to the whole browser process, so each set of features requires own browser
+// (e.g. a browser per context configuration)
+type Features struct {
+	EnableBlink  []string // --enable-blink-features, e.g. "CSSAnchorPositioning"
+	DisableBlink []string // --disable-blink-features
+	Enable       []string // --enable-features, e.g. "NetworkServiceInProcess"
+	Disable      []string // --disable-features
+	// OriginTrialPublicKey base64 public keys to accept origin trial tokens signed by (--origin-trial-public-key)
+	OriginTrialPublicKey []string
+}
+
+// Flags launcher flags, use with Launch. Chrome features are merged with the launcher defaults
+// because only the last --enable-features/--disable-features flag is applied
+func (f Features) Flags() []string {
+	var flags []string
+	for _, v := range []struct {
+		name   string
+		values []string
+	}{
+		{"--enable-blink-features", f.EnableBlink},
+		{"--disable-blink-features", f.DisableBlink},
+		{"--enable-features", mergeFeatures(defaultEnableFeatures, f.Enable)},
+		{"--disable-features", mergeFeatures(defaultDisableFeatures, f.Disable)},
+		{"--origin-trial-public-key", f.OriginTrialPublicKey},
+	} {
+		if len(v.values) > 0 {
+			flags = append(flags, v.name+"="+strings.Join(v.values, ","))
+		}
+	}
+	return flags
+}
+
+func mergeFeatures(defaults, features []string) []string {
+	if len(features) == 0 {
+		return nil
+	}
+	return append(append([]string{}, defaults...), features...)
+}
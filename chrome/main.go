@@ -3,9 +3,11 @@ package chrome
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
@@ -29,6 +31,9 @@ func (c Browser) GetClient() *transport.Client {
 
 // Close close browser
 func (c Browser) Close() error {
+	if c.cmd == nil { // connected to remote browser
+		return c.client.Close()
+	}
 	// Close close browser and websocket connection
 	exited := make(chan int, 1)
 	go func() {
@@ -47,6 +52,20 @@ func (c Browser) Close() error {
 	}
 }
 
+// ConstrainedFlags flags for running in CI containers with tight memory, use it with BrowserContext.Constrained
+var ConstrainedFlags = []string{
+	"--single-process",
+	"--no-zygote",
+	"--disable-dev-shm-usage",
+	"--renderer-process-limit=1",
+	"--js-flags=--max-old-space-size=512",
+}
+
+var (
+	defaultDisableFeatures = []string{"site-per-process", "Translate", "BlinkGenPropertyTrees"}
+	defaultEnableFeatures  = []string{"NetworkService", "NetworkServiceInProcess"}
+)
+
 // Launch launch a new browser process
 func Launch(ctx context.Context, userFlags ...string) (*Browser, error) {
 	browser := &Browser{context: ctx}
@@ -57,6 +76,7 @@ func Launch(ctx context.Context, userFlags ...string) (*Browser, error) {
 	bin := []string{
 		"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
 		"/usr/bin/google-chrome",
+		"chrome-headless-shell",
 		"headless-shell",
 		"browser",
 		"chromium",
@@ -103,8 +123,8 @@ func Launch(ctx context.Context, userFlags ...string) (*Browser, error) {
 		"--disable-ipc-flooding-protection",
 		"--disable-prompt-on-repost",
 		"--metrics-recording-only",
-		"--disable-features=site-per-process,Translate,BlinkGenPropertyTrees",
-		"--enable-features=NetworkService,NetworkServiceInProcess",
+		"--disable-features=" + strings.Join(defaultDisableFeatures, ","),
+		"--enable-features=" + strings.Join(defaultEnableFeatures, ","),
 		"--user-data-dir=" + browser.UserDataDir,
 	}
 
@@ -130,6 +150,58 @@ func Launch(ctx context.Context, userFlags ...string) (*Browser, error) {
 	return browser, err
 }
 
+// Connect connect to already running browser by its devtools http endpoint,
+// e.g. http://127.0.0.1:9222 forwarded by `adb forward tcp:9222 localabstract:chrome_devtools_remote`
+// or remote debugging port of Electron application
+func Connect(ctx context.Context, endpoint string) (*Browser, error) {
+	var version = struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}{}
+	if err := getJSON(ctx, endpoint, "/json/version", &version); err != nil {
+		return nil, err
+	}
+	if version.WebSocketDebuggerURL == "" {
+		return nil, fmt.Errorf("no webSocketDebuggerUrl at %s", endpoint)
+	}
+	var err error
+	browser := &Browser{context: ctx, webSocketURL: version.WebSocketDebuggerURL}
+	browser.client, err = transport.Dial(browser.webSocketURL)
+	return browser, err
+}
+
+// ConnectInspector connect to node inspector endpoint (e.g. Electron main process started with --inspect=9229)
+func ConnectInspector(ctx context.Context, endpoint string) (*transport.Client, error) {
+	var list []struct {
+		Type                 string `json:"type"`
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := getJSON(ctx, endpoint, "/json/list", &list); err != nil {
+		return nil, err
+	}
+	for _, t := range list {
+		if t.Type == "node" && t.WebSocketDebuggerURL != "" {
+			return transport.Dial(t.WebSocketDebuggerURL)
+		}
+	}
+	return nil, fmt.Errorf("no node inspector target at %s", endpoint)
+}
+
+func getJSON(ctx context.Context, endpoint, path string, v interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("GET %s: %s", request.URL, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
 func addrFromStderr(rc io.ReadCloser) (string, error) {
 	const prefix = "DevTools listening on"
 	var (
@@ -0,0 +1,79 @@
+package control
+
+import (
+	"time"
+)
+
+const nearMissCandidates = 5
+
+// ClickTextOptions options of ClickText
+type ClickTextOptions struct {
+	Exact   bool          // whole (whitespace normalized) text of element should be equal to text, otherwise element contains text
+	Timeout time.Duration // how long to wait for visible element with the text
+}
+
+// ClickText find element with the text in main frame, wait it is visible and click it
+func (s Session) ClickText(text string, opts ClickTextOptions) error {
+	return s.Page().ClickText(text, opts)
+}
+
+// ClickText see Session.ClickText
+func (f Frame) ClickText(text string, opts ClickTextOptions) error {
+	val, err := f.evaluate(`document.documentElement`, false, false)
+	if err != nil {
+		return err
+	}
+	root, err := f.constructElement(val)
+	if err != nil {
+		return err
+	}
+	return root.ClickTextWith(text, opts)
+}
+
+// ClickText click descendant element with the text
+func (e Element) ClickText(text string) error {
+	return e.ClickTextWith(text, ClickTextOptions{})
+}
+
+// ClickTextWith click descendant element with the text, waiting for it according to opts
+func (e Element) ClickTextWith(text string, opts ClickTextOptions) error {
+	var (
+		clock    = e.frame.session.Clock()
+		deadline = clock.Now().Add(opts.Timeout)
+	)
+	for {
+		target, err := e.FindByText(text, opts.Exact)
+		if err == nil {
+			if err = target.Click(); err == nil {
+				return nil
+			}
+		}
+		switch err.(type) {
+		case NoSuchTextError:
+		default:
+			if err != ErrNodeIsNotVisible && err != ErrNodeIsOutOfViewport {
+				return err
+			}
+		}
+		if clock.Now().After(deadline) {
+			return err
+		}
+		clock.Sleep(waitPollingInterval)
+	}
+}
+
+// FindByText find the deepest descendant element with the text
+func (e Element) FindByText(text string, exact bool) (*Element, error) {
+	val, err := e.CallFunction(functionFindByText, true, false, newCallArguments(text, exact))
+	if err != nil {
+		return nil, err
+	}
+	if val.ObjectId == "" {
+		notFound := NoSuchTextError{Text: text}
+		if candidates, err1 := e.CallFunction(functionNearMissText, true, false, newCallArguments(text, nearMissCandidates)); err1 == nil {
+			notFound.Candidates, _ = e.stringArray(candidates)
+		}
+		return nil, notFound
+	}
+	return e.frame.constructElement(val)
+}
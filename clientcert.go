@@ -0,0 +1,129 @@
+package control
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+// ClientCertificate TLS client certificate presented to the origin
+type ClientCertificate struct {
+	Origin      string // e.g. "https://admin.example.com"
+	Certificate tls.Certificate
+	RootCAs     *x509.CertPool // system roots if nil
+}
+
+// LoadClientCertificate read PEM encoded certificate and private key of the origin
+func LoadClientCertificate(origin, certFile, keyFile string) (ClientCertificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return ClientCertificate{}, err
+	}
+	return ClientCertificate{Origin: origin, Certificate: cert}, nil
+}
+
+func httpHeader(h *network.Headers) http.Header {
+	var header = http.Header{}
+	if h == nil {
+		return header
+	}
+	if m, ok := (*h).(map[string]interface{}); ok {
+		for name, value := range m {
+			for _, line := range strings.Split(fmt.Sprint(value), "\n") {
+				header.Add(name, line)
+			}
+		}
+	}
+	return header
+}
+
+// UseClientCertificates satisfy TLS client certificate challenges of the origins: the browser can't present
+// a certificate without OS certificate store and policies, so requests to the origins are intercepted and sent
+// by the client with the certificate, then fulfilled with the received response
+func (s Session) UseClientCertificates(certs ...ClientCertificate) (cancel func(), err error) {
+	var cancels []func()
+	cancel = func() {
+		for _, c := range cancels {
+			c()
+		}
+	}
+	for _, cert := range certs {
+		var client = &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					Certificates: []tls.Certificate{cert.Certificate},
+					RootCAs:      cert.RootCAs,
+				},
+			},
+			Timeout: s.browser.Client.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse // redirects are followed by the browser
+			},
+		}
+		var pattern = strings.TrimSuffix(cert.Origin, "/") + "/*"
+		c, err := s.Intercept(fetch.RequestPattern{UrlPattern: pattern}, func(route *Route) {
+			if err := s.sendWithCertificate(client, route); err != nil {
+				_ = route.Fail("ConnectionFailed")
+			}
+		})
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		cancels = append(cancels, c)
+	}
+	return cancel, nil
+}
+
+func (s Session) sendWithCertificate(client *http.Client, route *Route) error {
+	var (
+		body = route.Request.PostData
+		err  error
+	)
+	if route.Request.HasPostData && body == "" {
+		if body, err = s.Network.GetRequestPostData(network.RequestId(route.NetworkId)); err != nil {
+			return err
+		}
+	}
+	request, err := http.NewRequestWithContext(s.context, route.Request.Method, route.Request.Url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header = httpHeader(route.Request.Headers)
+	request.Header.Del("Accept-Encoding") // let the transport negotiate and decode gzip
+	// cookies are added by the browser after the request stage
+	cookies, err := s.Network.GetCookies(route.Request.Url)
+	if err != nil {
+		return err
+	}
+	for _, c := range cookies {
+		request.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	var headers []*fetch.HeaderEntry
+	for name, values := range response.Header {
+		for _, value := range values {
+			headers = append(headers, &fetch.HeaderEntry{Name: name, Value: value})
+		}
+	}
+	return route.FulfillWith(fetch.FulfillRequestArgs{
+		ResponseCode:    response.StatusCode,
+		ResponseHeaders: decodedBodyHeaders(headers), // body is decoded by the transport
+		Body:            data,
+	})
+}
@@ -0,0 +1,33 @@
+package control
+
+import (
+	"sync"
+
+	"github.com/ecwid/control/transport"
+)
+
+type sessionClock struct {
+	mx    sync.Mutex
+	value transport.Clock
+}
+
+// SetClock replace clock of implicit waits, polling and input delays of the session
+// (nil resets it to the clock of the client), e.g. transport.ManualClock to fast-forward waits in tests
+func (s Session) SetClock(clock transport.Clock) {
+	s.clock.mx.Lock()
+	defer s.clock.mx.Unlock()
+	s.clock.value = clock
+}
+
+// Clock clock of the session
+func (s Session) Clock() transport.Clock {
+	s.clock.mx.Lock()
+	defer s.clock.mx.Unlock()
+	if s.clock.value != nil {
+		return s.clock.value
+	}
+	if s.browser.Client.Clock != nil {
+		return s.browser.Client.Clock
+	}
+	return transport.SystemClock
+}
@@ -0,0 +1,100 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/browser"
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/emulation"
+	"github.com/ecwid/control/protocol/target"
+)
+
+// EmulationDefaults emulation applied to a page before its first document starts loading
+type EmulationDefaults struct {
+	Timezone    string
+	Locale      string
+	Geolocation *emulation.SetGeolocationOverrideArgs
+	Permissions []browser.PermissionType
+	UserAgent   string
+}
+
+// CreateIncognitoContext creates a new browser context, defaults are applied to every page created in it by CreatePageTargetIn
+func (b *BrowserContext) CreateIncognitoContext(defaults *EmulationDefaults) (common.BrowserContextID, error) {
+	val, err := target.CreateBrowserContext(b, target.CreateBrowserContextArgs{DisposeOnDetach: true})
+	if err != nil {
+		return "", err
+	}
+	if defaults != nil {
+		if len(defaults.Permissions) > 0 {
+			if err = b.GrantPermissionsIn(val.BrowserContextId, "", defaults.Permissions...); err != nil {
+				_ = b.DisposeIncognitoContext(val.BrowserContextId)
+				return "", err
+			}
+		}
+		b.defaults.Store(val.BrowserContextId, defaults)
+	}
+	return val.BrowserContextId, nil
+}
+
+// DisposeIncognitoContext closes browser context and all its pages
+func (b *BrowserContext) DisposeIncognitoContext(id common.BrowserContextID) error {
+	b.defaults.Delete(id)
+	return target.DisposeBrowserContext(b, target.DisposeBrowserContextArgs{BrowserContextId: id})
+}
+
+// CreatePageTargetIn creates page in the browser context (empty id means default context),
+// emulation defaults (or defaults of the browser context if nil) are applied before navigation to url
+func (b *BrowserContext) CreatePageTargetIn(contextID common.BrowserContextID, url string, defaults *EmulationDefaults) (*Session, error) {
+	if defaults == nil {
+		if val, ok := b.defaults.Load(contextID); ok {
+			defaults = val.(*EmulationDefaults)
+		}
+	}
+	r, err := target.CreateTarget(b, target.CreateTargetArgs{Url: Blank, BrowserContextId: contextID})
+	if err != nil {
+		if _, ok := err.(DomainUnavailableError); ok || isNotSupported(err) {
+			return nil, ErrCreateTargetNotSupported
+		}
+		return nil, err
+	}
+	session, err := b.AttachPageTarget(r.TargetId)
+	if err != nil {
+		_ = b.CloseTarget(r.TargetId)
+		return nil, err
+	}
+	if defaults != nil {
+		if err = session.Emulation.apply(defaults); err != nil {
+			_ = session.Close()
+			return nil, err
+		}
+	}
+	if url != "" && url != Blank {
+		if err = session.Page().Navigate(url, LifecycleLoad, b.Client.Timeout); err != nil {
+			_ = session.Close()
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+func (e Emulation) apply(d *EmulationDefaults) error {
+	if d.Timezone != "" {
+		if err := e.SetTimezone(d.Timezone); err != nil {
+			return err
+		}
+	}
+	if d.Locale != "" {
+		if err := e.SetLocale(d.Locale); err != nil {
+			return err
+		}
+	}
+	if d.Geolocation != nil {
+		if err := emulation.SetGeolocationOverride(e.s, *d.Geolocation); err != nil {
+			return err
+		}
+	}
+	if d.UserAgent != "" {
+		if err := e.SetUserAgentOverride(d.UserAgent, d.Locale, "", nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package control
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/network"
+)
+
+const (
+	SameSiteStrict network.CookieSameSite = "Strict"
+	SameSiteLax    network.CookieSameSite = "Lax"
+	SameSiteNone   network.CookieSameSite = "None"
+)
+
+// CookieExpires converts time to cookie expiration value
+func CookieExpires(t time.Time) common.TimeSinceEpoch {
+	return common.TimeSinceEpoch(float64(t.UnixNano()) / float64(time.Second))
+}
+
+// NewCookieParam converts cookie to param for SetCookie (session cookies have no expiration)
+func NewCookieParam(c *network.Cookie) *network.CookieParam {
+	var param = &network.CookieParam{
+		Name:         c.Name,
+		Value:        c.Value,
+		Domain:       c.Domain,
+		Path:         c.Path,
+		Secure:       c.Secure,
+		HttpOnly:     c.HttpOnly,
+		SameSite:     c.SameSite,
+		Priority:     c.Priority,
+		SameParty:    c.SameParty,
+		SourceScheme: c.SourceScheme,
+		SourcePort:   c.SourcePort,
+		PartitionKey: c.PartitionKey,
+	}
+	if !c.Session {
+		param.Expires = common.TimeSinceEpoch(c.Expires)
+	}
+	return param
+}
+
+// SetCookie ...
+func (n Network) SetCookie(cookie *network.CookieParam) error {
+	return n.SetCookies(cookie)
+}
+
+// GetAllCookies returns all browser cookies
+func (n Network) GetAllCookies() ([]*network.Cookie, error) {
+	val, err := network.GetAllCookies(n.s)
+	if err != nil {
+		return nil, err
+	}
+	return val.Cookies, nil
+}
+
+// DeleteCookies delete all browser cookies matched by matcher
+func (n Network) DeleteCookies(matcher func(*network.Cookie) bool) error {
+	cookies, err := n.GetAllCookies()
+	if err != nil {
+		return err
+	}
+	for _, c := range cookies {
+		if !matcher(c) {
+			continue
+		}
+		err = network.DeleteCookies(n.s, network.DeleteCookiesArgs{
+			Name:         c.Name,
+			Domain:       c.Domain,
+			Path:         c.Path,
+			PartitionKey: c.PartitionKey,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearCookies delete all browser cookies
+func (n Network) ClearCookies() error {
+	return n.ClearBrowserCookies()
+}
+
+// ExportCookies write all browser cookies to w as JSON array
+func (n Network) ExportCookies(w io.Writer) error {
+	cookies, err := n.GetAllCookies()
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(cookies)
+}
+
+// ImportCookies read JSON array of cookies written by ExportCookies and set them
+func (n Network) ImportCookies(r io.Reader) error {
+	var cookies []*network.Cookie
+	if err := json.NewDecoder(r).Decode(&cookies); err != nil {
+		return err
+	}
+	var params = make([]*network.CookieParam, len(cookies))
+	for i, c := range cookies {
+		params[i] = NewCookieParam(c)
+	}
+	return n.SetCookies(params...)
+}
@@ -0,0 +1,86 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ecwid/control/protocol/audits"
+	"github.com/ecwid/control/transport"
+)
+
+// CSPViolation content security policy violation reported by the page
+type CSPViolation struct {
+	BlockedURL        string
+	ViolatedDirective string // e.g. "script-src-elem"
+	ReportOnly        bool
+	Type              audits.ContentSecurityPolicyViolationType // e.g. "kInlineViolation"
+	SourceURL         string
+	LineNumber        int
+	ColumnNumber      int
+	Raw               *audits.ContentSecurityPolicyIssueDetails
+}
+
+func newCSPViolation(v *audits.ContentSecurityPolicyIssueDetails) CSPViolation {
+	var r = CSPViolation{
+		BlockedURL:        v.BlockedURL,
+		ViolatedDirective: v.ViolatedDirective,
+		ReportOnly:        v.IsReportOnly,
+		Type:              v.ContentSecurityPolicyViolationType,
+		Raw:               v,
+	}
+	if v.SourceCodeLocation != nil {
+		r.SourceURL = v.SourceCodeLocation.Url
+		r.LineNumber = v.SourceCodeLocation.LineNumber
+		r.ColumnNumber = v.SourceCodeLocation.ColumnNumber
+	}
+	return r
+}
+
+// OnCSPViolation subscribe to CSP violations reported as inspector issues, Audits domain must be enabled
+// (see CSPViolations)
+func (s Session) OnCSPViolation(handler func(CSPViolation)) (cancel func()) {
+	return s.Subscribe("Audits.issueAdded", func(e transport.Event) {
+		var v = audits.IssueAdded{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Issue == nil || v.Issue.Details == nil {
+			return
+		}
+		if details := v.Issue.Details.ContentSecurityPolicyIssueDetails; details != nil {
+			handler(newCSPViolation(details))
+		}
+	})
+}
+
+// CSPViolations enable Audits domain and returns channel of CSP violations of the page including ones
+// reported before the call. Violations are dropped if the channel buffer of given size is full,
+// channel is closed by cancel
+func (s Session) CSPViolations(buffer int) (violations <-chan CSPViolation, cancel func(), err error) {
+	var (
+		ch     = make(chan CSPViolation, buffer)
+		mx     sync.Mutex
+		closed bool
+	)
+	unsubscribe := s.OnCSPViolation(func(v CSPViolation) {
+		mx.Lock()
+		defer mx.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- v:
+		default:
+		}
+	})
+	if err = audits.Enable(s); err != nil {
+		unsubscribe()
+		return nil, nil, err
+	}
+	return ch, func() {
+		unsubscribe()
+		mx.Lock()
+		defer mx.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}, nil
+}
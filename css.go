@@ -0,0 +1,134 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/css"
+	"github.com/ecwid/control/protocol/dom"
+)
+
+// styleDisableAnimations stops CSS animations and transitions, hides caret and disables smooth scrolling
+const styleDisableAnimations = `*,*::before,*::after{animation-duration:0s!important;animation-delay:0s!important;transition-duration:0s!important;transition-delay:0s!important;caret-color:transparent!important;scroll-behavior:auto!important}`
+
+// StyleSheet inspector stylesheet of the frame, it lives until the frame navigates
+type StyleSheet struct {
+	id    css.StyleSheetId
+	frame *Frame
+}
+
+// enableCSS CSS domain requires enabled DOM domain
+func enableCSS(f *Frame) error {
+	if err := dom.Enable(f); err != nil {
+		return err
+	}
+	return css.Enable(f)
+}
+
+// AddStyleSheet add stylesheet with text to the main frame, see Frame.AddStyleSheet
+func (s Session) AddStyleSheet(text string) (*StyleSheet, error) {
+	return s.Page().AddStyleSheet(text)
+}
+
+// AddStyleSheet add inspector stylesheet with text to the frame. Frame has single inspector stylesheet,
+// so the text replaces text of stylesheet added before
+func (f Frame) AddStyleSheet(text string) (*StyleSheet, error) {
+	if err := enableCSS(&f); err != nil {
+		return nil, err
+	}
+	val, err := css.CreateStyleSheet(f, css.CreateStyleSheetArgs{FrameId: f.id})
+	if err != nil {
+		return nil, err
+	}
+	var sheet = &StyleSheet{id: val.StyleSheetId, frame: &f}
+	if err = sheet.SetText(text); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+// DisableAnimations add stylesheet that disables CSS animations and transitions of the main frame
+func (s Session) DisableAnimations() (*StyleSheet, error) {
+	return s.AddStyleSheet(styleDisableAnimations)
+}
+
+// ID ...
+func (s StyleSheet) ID() css.StyleSheetId {
+	return s.id
+}
+
+// Text returns current text of the stylesheet
+func (s StyleSheet) Text() (string, error) {
+	val, err := css.GetStyleSheetText(s.frame, css.GetStyleSheetTextArgs{StyleSheetId: s.id})
+	if err != nil {
+		return "", err
+	}
+	return val.Text, nil
+}
+
+// SetText replace text of the stylesheet
+func (s StyleSheet) SetText(text string) error {
+	_, err := css.SetStyleSheetText(s.frame, css.SetStyleSheetTextArgs{StyleSheetId: s.id, Text: text})
+	return err
+}
+
+// Remove clear text of the stylesheet
+func (s StyleSheet) Remove() error {
+	return s.SetText("")
+}
+
+// SetStyleSheetText replace text of any stylesheet of the page (e.g. from CSS.styleSheetAdded)
+func (s Session) SetStyleSheetText(id css.StyleSheetId, text string) error {
+	return StyleSheet{id: id, frame: s.Page()}.SetText(text)
+}
+
+// nodeID id of the element in DOM agent, it's required by CSS domain
+func (e Element) nodeID() (dom.NodeId, error) {
+	if err := enableCSS(e.frame); err != nil {
+		return 0, err
+	}
+	// node can be requested only after document was requested
+	if _, err := dom.GetDocument(e.frame, dom.GetDocumentArgs{}); err != nil {
+		return 0, err
+	}
+	val, err := dom.RequestNode(e.frame, dom.RequestNodeArgs{ObjectId: e.runtime.ObjectId})
+	if err != nil {
+		return 0, err
+	}
+	return val.NodeId, nil
+}
+
+// MatchedStyles returns inline style, matched rules, pseudo element rules and rules inherited from ancestors
+func (e Element) MatchedStyles() (*css.GetMatchedStylesForNodeVal, error) {
+	id, err := e.nodeID()
+	if err != nil {
+		return nil, err
+	}
+	return css.GetMatchedStylesForNode(e.frame, css.GetMatchedStylesForNodeArgs{NodeId: id})
+}
+
+// ComputedStyles returns all computed style properties of the element
+func (e Element) ComputedStyles() (map[string]string, error) {
+	id, err := e.nodeID()
+	if err != nil {
+		return nil, err
+	}
+	val, err := css.GetComputedStyleForNode(e.frame, css.GetComputedStyleForNodeArgs{NodeId: id})
+	if err != nil {
+		return nil, err
+	}
+	var styles = make(map[string]string, len(val.ComputedStyle))
+	for _, p := range val.ComputedStyle {
+		styles[p.Name] = p.Value
+	}
+	return styles, nil
+}
+
+// ForcePseudoState force pseudo classes of the element (e.g. "hover", "focus"), empty list resets it
+func (e Element) ForcePseudoState(classes ...string) error {
+	id, err := e.nodeID()
+	if err != nil {
+		return err
+	}
+	if classes == nil {
+		classes = []string{}
+	}
+	return css.ForcePseudoState(e.frame, css.ForcePseudoStateArgs{NodeId: id, ForcedPseudoClasses: classes})
+}
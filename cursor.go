@@ -0,0 +1,85 @@
+package control
+
+import "sync"
+
+// ShowCursor inject overlay into the current and every new document of the page and its out-of-process iframes
+// that draws the pointer position and ripples of clicks and taps, so screenshots and screencasts show what input does.
+// Overlay is rendered into closed shadow root, ignores pointer events and doesn't affect hit testing or mutation waits,
+// hide removes it
+func (s Session) ShowCursor() (hide func() error, err error) {
+	return s.showCursor(true)
+}
+
+// showCursor inject overlay, into the current document too if evaluate (attached iframe is paused and has no document yet)
+func (s Session) showCursor(evaluate bool) (hide func() error, err error) {
+	identifier, err := s.AddScriptToEvaluateOnNewDocument(functionCursorOverlay)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		mx     sync.Mutex
+		hides  []func() error
+		failed error // of iframe attached after ShowCursor
+	)
+	var child = func(c *Session, evaluate bool) error {
+		h, err := c.showCursor(evaluate)
+		if err != nil {
+			if c.State().IsTerminal() {
+				return nil
+			}
+			return err
+		}
+		mx.Lock()
+		hides = append(hides, func() error {
+			if err := h(); err != nil && !c.State().IsTerminal() {
+				return err
+			}
+			return nil
+		})
+		mx.Unlock()
+		return nil
+	}
+	cancel := s.onOOPIFAttached(func(c *Session) {
+		if err := child(c, false); err != nil {
+			mx.Lock()
+			if failed == nil {
+				failed = err
+			}
+			mx.Unlock()
+		}
+	})
+	hide = func() error {
+		cancel()
+		mx.Lock()
+		var rest, err = hides, failed
+		hides, failed = nil, nil
+		mx.Unlock()
+		for _, h := range rest {
+			if e := h(); e != nil && err == nil {
+				err = e
+			}
+		}
+		if e := s.RemoveScriptToEvaluateOnNewDocument(identifier); e != nil && err == nil {
+			err = e
+		}
+		if _, e := s.Evaluate(functionCursorOverlayRemove, false, false); e != nil && err == nil {
+			err = e
+		}
+		return err
+	}
+	if evaluate {
+		if _, err = s.Evaluate(functionCursorOverlay, false, false); err != nil {
+			_ = hide()
+			return nil, err
+		}
+	}
+	s.oopifs.Range(func(_, value interface{}) bool {
+		err = child(value.(*Session), evaluate)
+		return err == nil
+	})
+	if err != nil {
+		_ = hide()
+		return nil, err
+	}
+	return hide, nil
+}
@@ -0,0 +1,148 @@
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ecwid/control/protocol/runtime"
+)
+
+const deepSerialization = "deep"
+
+// EvaluateDeep evaluate expression in main frame, see Frame.EvaluateDeep
+func (s Session) EvaluateDeep(expression string, maxDepth int) (interface{}, error) {
+	return s.Page().EvaluateDeep(expression, maxDepth)
+}
+
+// EvaluateDeep evaluate expression (promise is awaited) with deep serialization of the result up to maxDepth:
+// objects and Maps are returned as map[string]interface{}, arrays and Sets as []interface{}, Dates as time.Time,
+// BigInts as *big.Int, NaN/Infinity/-0 as float64, RegExps as "/pattern/flags" and other objects as their type name
+func (f Frame) EvaluateDeep(expression string, maxDepth int) (interface{}, error) {
+	var cid, ok = f.session.executions.Load(f.id)
+	if !ok {
+		return nil, ErrExecutionContextDestroyed
+	}
+	val, err := runtime.Evaluate(f, runtime.EvaluateArgs{
+		Expression:   expression,
+		ContextId:    cid.(runtime.ExecutionContextId),
+		AwaitPromise: true,
+		SerializationOptions: &runtime.SerializationOptions{
+			Serialization: deepSerialization,
+			MaxDepth:      maxDepth,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	if val.Result.DeepSerializedValue == nil {
+		return val.Result.Value, nil
+	}
+	return deserialize(val.Result.DeepSerializedValue)
+}
+
+// deserialize converts deep serialized value to Go value
+func deserialize(v *runtime.DeepSerializedValue) (interface{}, error) {
+	var raw, err = json.Marshal(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	switch v.Type {
+	case "undefined", "null":
+		return nil, nil
+	case "string", "boolean":
+		return v.Value, nil
+	case "number":
+		switch special := v.Value.(type) {
+		case string:
+			switch special {
+			case "NaN":
+				return math.NaN(), nil
+			case "-0":
+				return math.Copysign(0, -1), nil
+			case "Infinity":
+				return math.Inf(1), nil
+			case "-Infinity":
+				return math.Inf(-1), nil
+			}
+		case float64:
+			return special, nil
+		}
+		return nil, fmt.Errorf("unexpected number value `%v`", v.Value)
+	case "bigint":
+		var s string
+		if err = json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("unexpected bigint value `%s`", s)
+		}
+		return n, nil
+	case "date":
+		var s string
+		if err = json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case "regexp":
+		var re = struct {
+			Pattern string `json:"pattern"`
+			Flags   string `json:"flags"`
+		}{}
+		if err = json.Unmarshal(raw, &re); err != nil {
+			return nil, err
+		}
+		return "/" + re.Pattern + "/" + re.Flags, nil
+	case "array", "set":
+		var items []*runtime.DeepSerializedValue
+		if err = json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		var list = make([]interface{}, len(items))
+		for i, item := range items {
+			if list[i], err = deserialize(item); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case "object", "map":
+		if v.Value == nil {
+			return v.Type, nil // depth limit reached
+		}
+		var entries [][2]json.RawMessage
+		if err = json.Unmarshal(raw, &entries); err != nil {
+			return nil, err
+		}
+		var object = make(map[string]interface{}, len(entries))
+		for _, entry := range entries {
+			var key string
+			if err = json.Unmarshal(entry[0], &key); err != nil {
+				// non-string key of Map is serialized value
+				var k = &runtime.DeepSerializedValue{}
+				if err = json.Unmarshal(entry[0], k); err != nil {
+					return nil, err
+				}
+				kv, err := deserialize(k)
+				if err != nil {
+					return nil, err
+				}
+				key = fmt.Sprint(kv)
+			}
+			var value = &runtime.DeepSerializedValue{}
+			if err = json.Unmarshal(entry[1], value); err != nil {
+				return nil, err
+			}
+			if object[key], err = deserialize(value); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	}
+	return v.Type, nil
+}
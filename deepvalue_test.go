@@ -0,0 +1,105 @@
+package control
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/runtime"
+)
+
+func TestDeserialize(t *testing.T) {
+	var cases = []struct {
+		name   string
+		value  string // JSON of runtime.DeepSerializedValue
+		expect interface{}
+		check  func(interface{}) bool // instead of expect for values that aren't comparable
+		fails  bool
+	}{
+		{name: "undefined", value: `{"type":"undefined"}`},
+		{name: "null", value: `{"type":"null"}`},
+		{name: "string", value: `{"type":"string","value":"text"}`, expect: "text"},
+		{name: "boolean", value: `{"type":"boolean","value":true}`, expect: true},
+		{name: "number", value: `{"type":"number","value":1.5}`, expect: 1.5},
+		{name: "NaN", value: `{"type":"number","value":"NaN"}`, check: func(v interface{}) bool { return math.IsNaN(v.(float64)) }},
+		{name: "-0", value: `{"type":"number","value":"-0"}`, check: func(v interface{}) bool { return v.(float64) == 0 && math.Signbit(v.(float64)) }},
+		{name: "Infinity", value: `{"type":"number","value":"Infinity"}`, expect: math.Inf(1)},
+		{name: "-Infinity", value: `{"type":"number","value":"-Infinity"}`, expect: math.Inf(-1)},
+		{name: "unexpected number", value: `{"type":"number","value":"1e"}`, fails: true},
+		{name: "bigint", value: `{"type":"bigint","value":"123456789012345678901234567890"}`, check: func(v interface{}) bool {
+			n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+			return v.(*big.Int).Cmp(n) == 0
+		}},
+		{name: "malformed bigint", value: `{"type":"bigint","value":"12n"}`, fails: true},
+		{name: "date", value: `{"type":"date","value":"2020-01-02T03:04:05.678Z"}`, expect: time.Date(2020, 1, 2, 3, 4, 5, 678000000, time.UTC)},
+		{name: "regexp", value: `{"type":"regexp","value":{"pattern":"a+b","flags":"gi"}}`, expect: "/a+b/gi"},
+		{
+			name:   "array and set",
+			value:  `{"type":"array","value":[{"type":"number","value":1},{"type":"set","value":[{"type":"string","value":"a"}]}]}`,
+			expect: []interface{}{1.0, []interface{}{"a"}},
+		},
+		{
+			name:   "object",
+			value:  `{"type":"object","value":[["a",{"type":"number","value":1}],["b",{"type":"object","value":[["c",{"type":"null"}]]}]]}`,
+			expect: map[string]interface{}{"a": 1.0, "b": map[string]interface{}{"c": nil}},
+		},
+		{
+			name:   "map with non-string keys",
+			value:  `{"type":"map","value":[[{"type":"number","value":1},{"type":"string","value":"one"}],["two",{"type":"number","value":2}]]}`,
+			expect: map[string]interface{}{"1": "one", "two": 2.0},
+		},
+		{name: "depth limit", value: `{"type":"object"}`, expect: "object"},
+		{name: "other objects", value: `{"type":"function"}`, expect: "function"},
+	}
+	for _, c := range cases {
+		var v = &runtime.DeepSerializedValue{}
+		if err := json.Unmarshal([]byte(c.value), v); err != nil {
+			t.Fatal(err)
+		}
+		got, err := deserialize(v)
+		if (err != nil) != c.fails {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		if c.fails {
+			continue
+		}
+		if c.check != nil && !c.check(got) || c.check == nil && !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("%s: unexpected value %#v", c.name, got)
+		}
+	}
+}
+
+func TestEvaluateDeep(t *testing.T) {
+	s, srv, log := testSession(t,
+		call(1, "Runtime.evaluate", ""),
+		reply(1, `{"result":{"type":"object","deepSerializedValue":{"type":"object","value":[["items",{"type":"array","value":[{"type":"number","value":"NaN"}]}]]}}}`),
+		call(2, "Runtime.evaluate", ""),
+		reply(2, `{"result":{"type":"number","value":1}}`),
+	)
+	s.executions.Store(common.FrameId(testTargetID), runtime.ExecutionContextId(7))
+	val, err := s.EvaluateDeep("({items: [NaN]})", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := val.(map[string]interface{})["items"].([]interface{}); len(items) != 1 || !math.IsNaN(items[0].(float64)) {
+		t.Fatalf("unexpected value %#v", val)
+	}
+	// value of result without deep serialization
+	if val, err = s.EvaluateDeep("1", 2); err != nil || val != 1.0 {
+		t.Fatalf("unexpected value %#v (%v)", val, err)
+	}
+	played(t, srv)
+	var args = runtime.EvaluateArgs{}
+	if err = json.Unmarshal(log.sent(t, "Runtime.evaluate")[0], &args); err != nil {
+		t.Fatal(err)
+	}
+	if args.ContextId != 7 || !args.AwaitPromise || args.SerializationOptions == nil ||
+		args.SerializationOptions.Serialization != deepSerialization || args.SerializationOptions.MaxDepth != 2 {
+		t.Fatalf("unexpected arguments %+v", args)
+	}
+}
@@ -0,0 +1,90 @@
+package control
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ecwid/control/protocol/target"
+)
+
+// SessionDescription a point-in-time snapshot of attached session
+type SessionDescription struct {
+	RunID          string
+	Label          string
+	SessionID      target.SessionID
+	TargetID       target.TargetID
+	URL            string
+	Title          string
+	State          SessionState
+	PendingActions int64
+	EventQueue     int
+	LastActivity   time.Time
+}
+
+type sessionStats struct {
+	label        atomic.Value
+	pending      int64
+	lastActivity int64
+}
+
+func newSessionStats() *sessionStats {
+	s := &sessionStats{lastActivity: time.Now().UnixNano()}
+	s.label.Store("")
+	return s
+}
+
+func (s *sessionStats) touch() {
+	atomic.StoreInt64(&s.lastActivity, time.Now().UnixNano())
+}
+
+func (s *sessionStats) callStarted() {
+	atomic.AddInt64(&s.pending, 1)
+	s.touch()
+}
+
+func (s *sessionStats) callFinished() {
+	atomic.AddInt64(&s.pending, -1)
+	s.touch()
+}
+
+// SetLabel human readable name of session used by Describe
+func (s Session) SetLabel(label string) {
+	s.stats.label.Store(label)
+}
+
+func (s Session) Label() string {
+	return s.stats.label.Load().(string)
+}
+
+// Describe returns snapshot of all sessions attached by this browser context
+func (b BrowserContext) Describe() ([]SessionDescription, error) {
+	targets, err := b.GetTargets()
+	if err != nil {
+		return nil, err
+	}
+	var infos = map[target.TargetID]*target.TargetInfo{}
+	for _, t := range targets {
+		infos[t.TargetId] = t
+	}
+	var list []SessionDescription
+	b.sessions.Range(func(_, value interface{}) bool {
+		s := value.(*Session)
+		d := SessionDescription{
+			RunID:          b.RunID,
+			Label:          s.Label(),
+			SessionID:      s.id,
+			TargetID:       s.tid,
+			State:          s.State(),
+			PendingActions: atomic.LoadInt64(&s.stats.pending),
+			EventQueue:     len(s.eventPool),
+			LastActivity:   time.Unix(0, atomic.LoadInt64(&s.stats.lastActivity)),
+		}
+		if info, ok := infos[s.tid]; ok {
+			d.URL = info.Url
+			d.Title = info.Title
+		}
+		list = append(list, d)
+		return true
+	})
+	return list, nil
+}
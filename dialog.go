@@ -0,0 +1,210 @@
+package control
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecwid/control/protocol/page"
+)
+
+type DialogPolicy int
+
+const (
+	DialogPolicyNone    DialogPolicy = iota // dialog is left opened and blocks the page until handled
+	DialogPolicyAccept                      // accept with default prompt
+	DialogPolicyDismiss                     // dismiss
+)
+
+const (
+	DialogAlert        page.DialogType = "alert"
+	DialogConfirm      page.DialogType = "confirm"
+	DialogPrompt       page.DialogType = "prompt"
+	DialogBeforeunload page.DialogType = "beforeunload"
+)
+
+// Dialog opened JavaScript dialog
+type Dialog struct {
+	page.JavascriptDialogOpening
+	session *Session
+	handled *int32
+}
+
+// Accept accept dialog, promptText is used for prompt dialog only
+func (d Dialog) Accept(promptText string) error {
+	return d.handle(true, promptText)
+}
+
+// Dismiss dismiss (cancel) dialog
+func (d Dialog) Dismiss() error {
+	return d.handle(false, "")
+}
+
+func (d Dialog) handle(accept bool, promptText string) error {
+	if !atomic.CompareAndSwapInt32(d.handled, 0, 1) {
+		return nil
+	}
+	return d.session.HandleJavaScriptDialog(accept, promptText)
+}
+
+// IsBeforeUnload true if it's "leave site?" dialog of beforeunload hook
+func (d Dialog) IsBeforeUnload() bool {
+	return d.Type == DialogBeforeunload
+}
+
+type dialogs struct {
+	mx           sync.Mutex
+	policy       DialogPolicy
+	beforeUnload DialogPolicy
+	seq          uint64
+	handlers     map[uint64]func(*Dialog)
+}
+
+func newDialogs() *dialogs {
+	return &dialogs{handlers: map[uint64]func(*Dialog){}}
+}
+
+// opening handlers are called (or policy applied if there are no handlers) outside of event loop
+// because handling of dialog blocks until page is unblocked
+func (d *dialogs) opening(s *Session, v page.JavascriptDialogOpening) {
+	var dialog = &Dialog{JavascriptDialogOpening: v, session: s, handled: new(int32)}
+	d.mx.Lock()
+	var (
+		policy   = d.policy
+		handlers = make([]func(*Dialog), 0, len(d.handlers))
+	)
+	for _, h := range d.handlers {
+		handlers = append(handlers, h)
+	}
+	if dialog.IsBeforeUnload() {
+		policy = d.beforeUnload
+	}
+	d.mx.Unlock()
+	if len(handlers) > 0 {
+		for _, h := range handlers {
+			go h(dialog)
+		}
+		return
+	}
+	switch policy {
+	case DialogPolicyAccept:
+		go func() { _ = dialog.Accept(v.DefaultPrompt) }()
+	case DialogPolicyDismiss:
+		go func() { _ = dialog.Dismiss() }()
+	}
+}
+
+// SetDialogPolicy how to handle dialogs when there are no OnDialog handlers
+func (s Session) SetDialogPolicy(policy DialogPolicy) {
+	s.dialogs.mx.Lock()
+	defer s.dialogs.mx.Unlock()
+	s.dialogs.policy = policy
+}
+
+// SetBeforeUnloadPolicy how to handle beforeunload dialogs when there are no OnDialog handlers,
+// DialogPolicyAccept leaves the page, DialogPolicyDismiss stays on the page
+func (s Session) SetBeforeUnloadPolicy(policy DialogPolicy) {
+	s.dialogs.mx.Lock()
+	defer s.dialogs.mx.Unlock()
+	s.dialogs.beforeUnload = policy
+}
+
+// CloseWithBeforeUnload close the page running its beforeunload hooks, if the page asks confirmation
+// then leave=true closes the page and leave=false keeps it opened. Returns true if the page was closed
+func (s Session) CloseWithBeforeUnload(leave bool, timeout time.Duration) (bool, error) {
+	var handled = make(chan struct{}, 1)
+	cancel := s.OnDialog(func(d *Dialog) {
+		if d.IsBeforeUnload() {
+			_ = d.handle(leave, "")
+			select {
+			case handled <- struct{}{}:
+			default:
+			}
+		}
+	})
+	defer cancel()
+	if err := page.Close(s); err != nil {
+		return false, err
+	}
+	var deadline = s.Clock().NewTimer(timeout)
+	defer deadline.Stop()
+	select {
+	case <-s.context.Done():
+		return true, nil
+	case <-handled:
+		if !leave {
+			return false, nil
+		}
+	case <-deadline.C():
+		return false, FutureTimeoutError{timeout: timeout}
+	}
+	select {
+	case <-s.context.Done():
+		return true, nil
+	case <-deadline.C():
+		return false, FutureTimeoutError{timeout: timeout}
+	}
+}
+
+// OnDialog register dialog handler, handler should Accept or Dismiss the dialog.
+// Dialog policy is not applied while there are registered handlers
+func (s Session) OnDialog(handler func(*Dialog)) (cancel func()) {
+	s.dialogs.mx.Lock()
+	s.dialogs.seq++
+	var id = s.dialogs.seq
+	s.dialogs.handlers[id] = handler
+	s.dialogs.mx.Unlock()
+	return func() {
+		s.dialogs.mx.Lock()
+		delete(s.dialogs.handlers, id)
+		s.dialogs.mx.Unlock()
+	}
+}
+
+// ExpectDialog performs action that opens dialog, accepts or dismisses it and waits action completion.
+// Action is performed in separate goroutine because it may be blocked by the dialog (e.g. evaluation of alert())
+func (s Session) ExpectDialog(action func() error, accept bool, promptText string, timeout time.Duration) (*Dialog, error) {
+	var opened = make(chan *Dialog, 1)
+	cancel := s.OnDialog(func(d *Dialog) {
+		select {
+		case opened <- d:
+		default:
+		}
+	})
+	defer cancel()
+	var done = make(chan error, 1)
+	go func() { done <- action() }()
+	var deadline = s.Clock().NewTimer(timeout)
+	defer deadline.Stop()
+	select {
+	case d := <-opened:
+		if err := d.handle(accept, promptText); err != nil {
+			return d, err
+		}
+		// action may be still blocked, e.g. the next dialog is opened
+		select {
+		case err := <-done:
+			return d, err
+		case <-s.context.Done():
+			return d, s.context.Err()
+		case <-deadline.C():
+			return d, FutureTimeoutError{timeout: timeout}
+		}
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case d := <-opened:
+			return d, d.handle(accept, promptText)
+		case <-s.context.Done():
+			return nil, s.context.Err()
+		case <-deadline.C():
+			return nil, FutureTimeoutError{timeout: timeout}
+		}
+	case <-s.context.Done():
+		return nil, s.context.Err()
+	case <-deadline.C():
+		return nil, FutureTimeoutError{timeout: timeout}
+	}
+}
@@ -0,0 +1,215 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/dom"
+	"github.com/ecwid/control/protocol/domsnapshot"
+)
+
+// SnapshotDocument document of DOM snapshot, documents of iframes are linked by SnapshotNode.ContentDocument
+type SnapshotDocument struct {
+	URL           string
+	Title         string
+	BaseURL       string
+	FrameID       string
+	ScrollOffsetX float64
+	ScrollOffsetY float64
+	ContentWidth  float64
+	ContentHeight float64
+	Root          *SnapshotNode
+}
+
+// SnapshotNode node of DOM snapshot with its layout (if node is rendered)
+type SnapshotNode struct {
+	NodeType       int
+	NodeName       string
+	NodeValue      string
+	BackendNodeID  dom.BackendNodeId
+	Attributes     map[string]string
+	TextValue      string
+	InputValue     string
+	InputChecked   bool
+	OptionSelected bool
+	IsClickable    bool
+	PseudoType     string
+	// Layout is nil if the node has no layout object
+	Layout          *SnapshotLayout
+	ContentDocument *SnapshotDocument
+	Children        []*SnapshotNode
+}
+
+// SnapshotLayout bounds, rendered text and requested computed styles of the node
+type SnapshotLayout struct {
+	Bounds dom.Rect
+	Text   string
+	Styles map[string]string
+}
+
+type snapshotStrings []string
+
+func (s snapshotStrings) get(i domsnapshot.StringIndex) string {
+	if i < 0 || int(i) >= len(s) {
+		return ""
+	}
+	return s[i]
+}
+
+func (s snapshotStrings) rare(data *domsnapshot.RareStringData) map[int]string {
+	var values = map[int]string{}
+	if data != nil {
+		for i, index := range data.Index {
+			if i < len(data.Value) {
+				values[index] = s.get(data.Value[i])
+			}
+		}
+	}
+	return values
+}
+
+func rareBoolean(data *domsnapshot.RareBooleanData) map[int]bool {
+	var values = map[int]bool{}
+	if data != nil {
+		for _, index := range data.Index {
+			values[index] = true
+		}
+	}
+	return values
+}
+
+func snapshotRect(r domsnapshot.Rectangle) dom.Rect {
+	if len(r) < 4 {
+		return dom.Rect{}
+	}
+	return dom.Rect{X: r[0], Y: r[1], Width: r[2], Height: r[3]}
+}
+
+// CaptureDOMSnapshot capture DOM tree of the page (including iframes) with layout and given computed styles
+func (s Session) CaptureDOMSnapshot(computedStyles ...string) (*SnapshotDocument, error) {
+	if computedStyles == nil {
+		computedStyles = []string{}
+	}
+	val, err := domsnapshot.CaptureSnapshot(s, domsnapshot.CaptureSnapshotArgs{ComputedStyles: computedStyles})
+	if err != nil {
+		return nil, err
+	}
+	if len(val.Documents) == 0 {
+		return nil, ErrNoDocuments
+	}
+	var (
+		strings   = snapshotStrings(val.Strings)
+		documents = make([]*SnapshotDocument, len(val.Documents))
+		nodes     = make([][]*SnapshotNode, len(val.Documents))
+		contents  = make([]map[int]int, len(val.Documents))
+	)
+	for i, d := range val.Documents {
+		documents[i], nodes[i], contents[i] = strings.document(d, computedStyles)
+	}
+	// link iframe owners with their documents
+	for i := range documents {
+		for node, document := range contents[i] {
+			if node < len(nodes[i]) && document >= 0 && document < len(documents) {
+				nodes[i][node].ContentDocument = documents[document]
+			}
+		}
+	}
+	return documents[0], nil
+}
+
+// document builds node tree of the document, returns it with its nodes in snapshot order
+// and index of content document by node index
+func (s snapshotStrings) document(d *domsnapshot.DocumentSnapshot, computedStyles []string) (*SnapshotDocument, []*SnapshotNode, map[int]int) {
+	var (
+		document = &SnapshotDocument{
+			URL:           s.get(d.DocumentURL),
+			Title:         s.get(d.Title),
+			BaseURL:       s.get(d.BaseURL),
+			FrameID:       s.get(d.FrameId),
+			ScrollOffsetX: d.ScrollOffsetX,
+			ScrollOffsetY: d.ScrollOffsetY,
+			ContentWidth:  d.ContentWidth,
+			ContentHeight: d.ContentHeight,
+		}
+		tree     = d.Nodes
+		contents = map[int]int{}
+	)
+	if tree == nil || len(tree.ParentIndex) == 0 {
+		return document, nil, contents
+	}
+	var (
+		textValue      = s.rare(tree.TextValue)
+		inputValue     = s.rare(tree.InputValue)
+		pseudoType     = s.rare(tree.PseudoType)
+		inputChecked   = rareBoolean(tree.InputChecked)
+		optionSelected = rareBoolean(tree.OptionSelected)
+		isClickable    = rareBoolean(tree.IsClickable)
+		nodes          = make([]*SnapshotNode, len(tree.ParentIndex))
+	)
+	for i := range nodes {
+		var node = &SnapshotNode{
+			TextValue:      textValue[i],
+			InputValue:     inputValue[i],
+			PseudoType:     pseudoType[i],
+			InputChecked:   inputChecked[i],
+			OptionSelected: optionSelected[i],
+			IsClickable:    isClickable[i],
+		}
+		if i < len(tree.NodeType) {
+			node.NodeType = tree.NodeType[i]
+		}
+		if i < len(tree.NodeName) {
+			node.NodeName = s.get(tree.NodeName[i])
+		}
+		if i < len(tree.NodeValue) {
+			node.NodeValue = s.get(tree.NodeValue[i])
+		}
+		if i < len(tree.BackendNodeId) {
+			node.BackendNodeID = tree.BackendNodeId[i]
+		}
+		if i < len(tree.Attributes) && len(tree.Attributes[i]) > 0 {
+			node.Attributes = map[string]string{}
+			for a := 0; a+1 < len(tree.Attributes[i]); a += 2 {
+				node.Attributes[s.get(tree.Attributes[i][a])] = s.get(tree.Attributes[i][a+1])
+			}
+		}
+		nodes[i] = node
+	}
+	if tree.ContentDocumentIndex != nil {
+		for i, index := range tree.ContentDocumentIndex.Index {
+			if i < len(tree.ContentDocumentIndex.Value) {
+				contents[index] = tree.ContentDocumentIndex.Value[i]
+			}
+		}
+	}
+	if layout := d.Layout; layout != nil {
+		for i, index := range layout.NodeIndex {
+			if index < 0 || index >= len(nodes) {
+				continue
+			}
+			var l = &SnapshotLayout{}
+			if i < len(layout.Bounds) {
+				l.Bounds = snapshotRect(layout.Bounds[i])
+			}
+			if i < len(layout.Text) {
+				l.Text = s.get(layout.Text[i])
+			}
+			if i < len(layout.Styles) && len(layout.Styles[i]) > 0 {
+				l.Styles = make(map[string]string, len(computedStyles))
+				for k, value := range layout.Styles[i] {
+					if k < len(computedStyles) {
+						l.Styles[computedStyles[k]] = s.get(value)
+					}
+				}
+			}
+			nodes[index].Layout = l
+		}
+	}
+	for i, parent := range tree.ParentIndex {
+		if parent < 0 || parent >= len(nodes) {
+			if document.Root == nil {
+				document.Root = nodes[i]
+			}
+			continue
+		}
+		nodes[parent].Children = append(nodes[parent].Children, nodes[i])
+	}
+	return document, nodes, contents
+}
@@ -0,0 +1,102 @@
+package control
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecwid/control/protocol/dom"
+	"github.com/ecwid/control/protocol/domsnapshot"
+)
+
+const testSnapshot = `{
+	"strings": ["https://example.com/", "Page", "#document", "HTML", "BODY", "IFRAME", "id", "main", "#text", "hello",
+		"INPUT", "typed", "display", "block", "https://frame.example.com/", "F1", "F2"],
+	"documents": [
+		{
+			"documentURL": 0, "title": 1, "baseURL": 0, "frameId": 15, "contentWidth": 800, "contentHeight": 600,
+			"nodes": {
+				"parentIndex": [-1, 0, 1, 2, 2, 2],
+				"nodeType": [9, 1, 1, 3, 1, 1],
+				"nodeName": [2, 3, 4, 8, 10, 5],
+				"nodeValue": [-1, -1, -1, 9, -1, -1],
+				"backendNodeId": [1, 2, 3, 4, 5, 6],
+				"attributes": [[], [], [6, 7], [], [], []],
+				"inputValue": {"index": [4], "value": [11]},
+				"inputChecked": {"index": [4]},
+				"isClickable": {"index": [4, 5]},
+				"contentDocumentIndex": {"index": [5], "value": [1]}
+			},
+			"layout": {
+				"nodeIndex": [2, 3],
+				"bounds": [[0, 0, 100, 50], [1, 2, 3, 4]],
+				"text": [-1, 9],
+				"styles": [[13], []],
+				"stackingContexts": {"index": []}
+			},
+			"textBoxes": {"layoutIndex": [], "bounds": [], "start": [], "length": []}
+		},
+		{
+			"documentURL": 14, "title": -1, "baseURL": 14, "frameId": 16,
+			"nodes": {"parentIndex": [-1], "nodeType": [9], "nodeName": [2], "backendNodeId": [7]},
+			"layout": {"nodeIndex": [], "bounds": [], "text": [], "styles": [], "stackingContexts": {"index": []}},
+			"textBoxes": {"layoutIndex": [], "bounds": [], "start": [], "length": []}
+		}
+	]
+}`
+
+func TestCaptureDOMSnapshot(t *testing.T) {
+	s, srv, _ := testSession(t,
+		call(1, "DOMSnapshot.captureSnapshot", ""),
+		reply(1, testSnapshot),
+		call(2, "DOMSnapshot.captureSnapshot", ""),
+		reply(2, `{"documents":[],"strings":[]}`),
+	)
+	document, err := s.CaptureDOMSnapshot("display")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frame = &SnapshotDocument{
+		URL: "https://frame.example.com/", BaseURL: "https://frame.example.com/", FrameID: "F2",
+		Root: &SnapshotNode{NodeType: 9, NodeName: "#document", BackendNodeID: 7},
+	}
+	var expect = &SnapshotDocument{
+		URL: "https://example.com/", Title: "Page", BaseURL: "https://example.com/", FrameID: "F1",
+		ContentWidth: 800, ContentHeight: 600,
+		Root: &SnapshotNode{NodeType: 9, NodeName: "#document", BackendNodeID: 1, Children: []*SnapshotNode{
+			{NodeType: 1, NodeName: "HTML", BackendNodeID: 2, Children: []*SnapshotNode{
+				{
+					NodeType: 1, NodeName: "BODY", BackendNodeID: 3,
+					Attributes: map[string]string{"id": "main"},
+					Layout:     &SnapshotLayout{Bounds: dom.Rect{Width: 100, Height: 50}, Styles: map[string]string{"display": "block"}},
+					Children: []*SnapshotNode{
+						{NodeType: 3, NodeName: "#text", NodeValue: "hello", BackendNodeID: 4, Layout: &SnapshotLayout{Bounds: dom.Rect{X: 1, Y: 2, Width: 3, Height: 4}, Text: "hello"}},
+						{NodeType: 1, NodeName: "INPUT", BackendNodeID: 5, InputValue: "typed", InputChecked: true, IsClickable: true},
+						{NodeType: 1, NodeName: "IFRAME", BackendNodeID: 6, IsClickable: true, ContentDocument: frame},
+					},
+				},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(document, expect) {
+		t.Fatalf("unexpected snapshot %s", jsonString(t, document))
+	}
+	if _, err = s.CaptureDOMSnapshot(); err != ErrNoDocuments {
+		t.Fatalf("expected ErrNoDocuments, got %v", err)
+	}
+	played(t, srv)
+}
+
+func TestSnapshotStrings(t *testing.T) {
+	var s = snapshotStrings{"a", "b"}
+	var cases = []struct {
+		index  int
+		expect string
+	}{
+		{0, "a"}, {1, "b"}, {2, ""}, {-1, ""},
+	}
+	for _, c := range cases {
+		if got := s.get(domsnapshot.StringIndex(c.index)); got != c.expect {
+			t.Errorf("index %d: expected `%s`, got `%s`", c.index, c.expect, got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package control
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	"github.com/ecwid/control/protocol/browser"
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	DownloadBehaviorDeny         = "deny"
+	DownloadBehaviorAllow        = "allow"
+	DownloadBehaviorAllowAndName = "allowAndName" // file is named by download guid
+	DownloadBehaviorDefault      = "default"
+)
+
+const (
+	DownloadInProgress = "inProgress"
+	DownloadCompleted  = "completed"
+	DownloadCanceled   = "canceled"
+)
+
+type downloadConfig struct {
+	behavior string
+	path     string
+}
+
+// Download finished download
+type Download struct {
+	GUID              string
+	URL               string
+	SuggestedFilename string
+	Path              string // final path of the file (if download path is configured)
+	State             string
+	TotalBytes        float64
+	ReceivedBytes     float64
+}
+
+// SetDownloadBehavior set download behavior and directory of browser context (empty id means default context)
+// with download events enabled
+func (b BrowserContext) SetDownloadBehavior(contextID common.BrowserContextID, behavior, downloadPath string) error {
+	err := browser.SetDownloadBehavior(b, browser.SetDownloadBehaviorArgs{
+		Behavior:         behavior,
+		BrowserContextId: contextID,
+		DownloadPath:     downloadPath,
+		EventsEnabled:    true,
+	})
+	if err != nil {
+		return err
+	}
+	b.downloads.Store(contextID, downloadConfig{behavior: behavior, path: downloadPath})
+	return nil
+}
+
+func (s Session) downloadConfig() downloadConfig {
+	if val, ok := s.browser.downloads.Load(s.tid); ok {
+		return val.(downloadConfig)
+	}
+	if info, err := s.browser.targetInfo(s.tid); err == nil {
+		if val, ok := s.browser.downloads.Load(info.BrowserContextId); ok {
+			return val.(downloadConfig)
+		}
+	}
+	if val, ok := s.browser.downloads.Load(common.BrowserContextID("")); ok {
+		return val.(downloadConfig)
+	}
+	return downloadConfig{}
+}
+
+// WaitForDownload performs action that starts download and waits until download of the page is completed or canceled
+func (s Session) WaitForDownload(action func() error, timeout time.Duration) (*Download, error) {
+	var download *Download
+	future := s.Observe("*", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		switch value.Method {
+		case "Browser.downloadWillBegin", "Page.downloadWillBegin":
+			var v = browser.DownloadWillBegin{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if _, ok := s.executions.Load(v.FrameId); download == nil && (ok || v.FrameId == common.FrameId(s.tid)) {
+				download = &Download{GUID: v.Guid, URL: v.Url, SuggestedFilename: v.SuggestedFilename, State: DownloadInProgress}
+			}
+		case "Browser.downloadProgress", "Page.downloadProgress":
+			var v = browser.DownloadProgress{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if download != nil && v.Guid == download.GUID {
+				download.State = v.State
+				download.TotalBytes = v.TotalBytes
+				download.ReceivedBytes = v.ReceivedBytes
+				if v.State != DownloadInProgress {
+					resolve(download)
+				}
+			}
+		}
+	})
+	defer future.Cancel()
+	if err := action(); err != nil {
+		return nil, err
+	}
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	download = val.(*Download)
+	if config := s.downloadConfig(); config.path != "" {
+		var name = download.SuggestedFilename
+		if config.behavior == DownloadBehaviorAllowAndName {
+			name = download.GUID
+		}
+		download.Path = filepath.Join(config.path, name)
+	}
+	return download, nil
+}
@@ -0,0 +1,88 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/ecwid/control/protocol/runtime"
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+)
+
+const targetTypePage = "page"
+
+// isNotSupported Electron and embedded browsers reject unsupported methods with generic error
+func isNotSupported(err error) bool {
+	if v, ok := err.(*transport.Error); ok {
+		return strings.Contains(strings.ToLower(v.Message), "not supported")
+	}
+	return false
+}
+
+// GetPageTargets returns all page targets, for Electron application these are BrowserWindow's web contents
+func (b BrowserContext) GetPageTargets() ([]*target.TargetInfo, error) {
+	targets, err := b.GetTargets()
+	if err != nil {
+		return nil, err
+	}
+	var pages []*target.TargetInfo
+	for _, t := range targets {
+		if t.Type == targetTypePage {
+			pages = append(pages, t)
+		}
+	}
+	return pages, nil
+}
+
+// AttachPageTargets attach to all page targets (e.g. windows of Electron application), if one of them
+// fails then targets attached so far are detached (not closed)
+func (b *BrowserContext) AttachPageTargets() ([]*Session, error) {
+	pages, err := b.GetPageTargets()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*Session
+	for _, p := range pages {
+		s, err1 := b.AttachPageTarget(p.TargetId)
+		if err1 != nil {
+			for _, attached := range sessions {
+				_ = target.DetachFromTarget(b, target.DetachFromTargetArgs{SessionId: attached.id})
+			}
+			return nil, err1
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// NodeContext evaluation in node.js context, e.g. Electron main process connected by chrome.ConnectInspector
+type NodeContext struct {
+	Client *transport.Client
+}
+
+func NewNodeContext(client *transport.Client) *NodeContext {
+	return &NodeContext{Client: client}
+}
+
+func (n NodeContext) Call(method string, send, recv interface{}) error {
+	return n.Client.Call("", method, send, recv)
+}
+
+func (n NodeContext) Evaluate(expression string, await, returnByValue bool) (interface{}, error) {
+	val, err := runtime.Evaluate(n, runtime.EvaluateArgs{
+		Expression:            expression,
+		IncludeCommandLineAPI: true,
+		AwaitPromise:          await,
+		ReturnByValue:         returnByValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	return val.Result.Value, nil
+}
+
+func (n NodeContext) Close() error {
+	return n.Client.Disconnect()
+}
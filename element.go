@@ -7,6 +7,7 @@ import (
 
 	"github.com/ecwid/control/protocol/dom"
 	"github.com/ecwid/control/protocol/input"
+	"github.com/ecwid/control/protocol/page"
 	"github.com/ecwid/control/protocol/runtime"
 )
 
@@ -21,9 +22,15 @@ func (f Frame) constructElement(object *runtime.RemoteObject) (*Element, error)
 }
 
 type Element struct {
-	runtime *runtime.RemoteObject
-	node    *dom.Node
-	frame   *Frame
+	runtime  *runtime.RemoteObject
+	node     *dom.Node
+	frame    *Frame
+	selector string // path of the element for action history
+}
+
+// IsDetached true if session of the element was closed, detached or crashed
+func (e Element) IsDetached() bool {
+	return e.frame.session.State().IsTerminal()
 }
 
 func (e Element) Description() string {
@@ -39,7 +46,12 @@ func (e Element) QuerySelector(selector string) (*Element, error) {
 	if err != nil {
 		return nil, err
 	}
-	return e.frame.constructElement(val)
+	el, err := e.frame.constructElement(val)
+	if err != nil {
+		return nil, err
+	}
+	el.selector = e.path() + " " + selector
+	return el, nil
 }
 
 func (e Element) CallFunction(function string, await, returnByValue bool, args []*runtime.CallArgument) (*runtime.RemoteObject, error) {
@@ -69,9 +81,17 @@ func (e Element) dispatchEvents(events ...string) error {
 }
 
 func (e Element) ScrollIntoView() error {
-	return dom.ScrollIntoViewIfNeeded(e.frame, dom.ScrollIntoViewIfNeededArgs{
+	err := dom.ScrollIntoViewIfNeeded(e.frame, dom.ScrollIntoViewIfNeededArgs{
 		BackendNodeId: e.node.BackendNodeId,
 	})
+	if err != nil {
+		return err
+	}
+	offset := e.frame.session.scrollOffset.get()
+	if offset.Top > 0 || offset.Auto {
+		_, err = e.CallFunction(functionScrollOffset, true, false, newCallArguments(offset.Top, offset.Auto))
+	}
+	return err
 }
 
 func (e Element) GetText() (string, error) {
@@ -87,7 +107,14 @@ func (e Element) Clear() error {
 	return err
 }
 
-func (e Element) InsertText(text string) error {
+func (e Element) InsertText(text string) (err error) {
+	e.frame.session.slowDown()
+	defer e.frame.lockActions()()
+	defer func() { e.record(ActionInput, err) }()
+	return e.insertText(text)
+}
+
+func (e Element) insertText(text string) error {
 	var err error
 	if err = e.ScrollIntoView(); err != nil {
 		return err
@@ -98,7 +125,7 @@ func (e Element) InsertText(text string) error {
 	if err = e.Clear(); err != nil {
 		return err
 	}
-	if err = e.frame.Session().Input.InsertText(text); err != nil {
+	if err = e.frame.Session().Input.insertText(text); err != nil {
 		return err
 	}
 	if err = e.dispatchEvents(
@@ -113,8 +140,10 @@ func (e Element) InsertText(text string) error {
 }
 
 // Type ...
-func (e *Element) Type(text string, delay time.Duration) error {
-	var err error
+func (e *Element) Type(text string, delay time.Duration) (err error) {
+	e.frame.session.slowDown()
+	defer e.frame.lockActions()()
+	defer func() { e.record(ActionType, err) }()
 	if err = e.ScrollIntoView(); err != nil {
 		return err
 	}
@@ -126,15 +155,15 @@ func (e *Element) Type(text string, delay time.Duration) error {
 	}
 	for _, c := range text {
 		if isKey(c) {
-			if err = e.frame.Session().Input.Press(keyDefinitions[c]); err != nil {
+			if err = e.frame.Session().Input.press(keyDefinitions[c]); err != nil {
 				return err
 			}
 		} else {
-			if err = e.InsertText(string(c)); err != nil {
+			if err = e.insertText(string(c)); err != nil {
 				return err
 			}
 		}
-		time.Sleep(delay)
+		e.frame.session.Clock().Sleep(delay)
 	}
 	if text == "" {
 		return e.dispatchEvents(
@@ -197,7 +226,10 @@ func (e Element) Click() error {
 	return e.ClickWith(MouseLeft, time.Millisecond*10)
 }
 
-func (e Element) ClickWith(button input.MouseButton, delayToRelease time.Duration) error {
+func (e Element) ClickWith(button input.MouseButton, delayToRelease time.Duration) (err error) {
+	e.frame.session.slowDown()
+	defer e.frame.lockActions()()
+	defer func() { e.record(ActionClick, err) }()
 	if err := e.ScrollIntoView(); err != nil {
 		return err
 	}
@@ -217,18 +249,18 @@ func (e Element) ClickWith(button input.MouseButton, delayToRelease time.Duratio
 	if err != nil {
 		return err
 	}
-	if err = e.frame.Session().Input.Click(button, x, y, delayToRelease); err != nil {
+	if err = e.frame.Session().Input.click(button, x, y, delayToRelease); err != nil {
 		return err
 	}
 	const timeout = time.Millisecond * 1000
-	var deadline = time.NewTimer(timeout)
+	var deadline = e.frame.session.Clock().NewTimer(timeout)
 	defer deadline.Stop()
 	select {
 	case v := <-clickValue:
 		if v != "1" {
 			return ClickTargetOverlappedError{X: x, Y: y, outerHTML: v}
 		}
-	case <-deadline.C:
+	case <-deadline.C():
 		return ErrClickTimeout
 	}
 	return nil
@@ -245,7 +277,10 @@ func (e Element) Upload(files ...string) error {
 	})
 }
 
-func (e Element) Hover() error {
+func (e Element) Hover() (err error) {
+	e.frame.session.slowDown()
+	defer e.frame.lockActions()()
+	defer func() { e.record(ActionHover, err) }()
 	if err := e.ScrollIntoView(); err != nil {
 		return err
 	}
@@ -253,7 +288,7 @@ func (e Element) Hover() error {
 	if err != nil {
 		return err
 	}
-	return e.frame.Session().Input.MouseMove(MouseNone, x, y)
+	return e.frame.Session().Input.moveTo(x, y)
 }
 
 func (e Element) SetAttribute(attr string, value string) error {
@@ -301,6 +336,23 @@ func (e Element) GetRectangle() (*dom.Rect, error) {
 	return rect, nil
 }
 
+// GetRectangleInView scroll the element into view and returns its rectangle in the viewport,
+// scroll positions are restored if SetScrollRestore is on (the rectangle is of the scrolled layout then)
+func (e Element) GetRectangleInView() (rect *dom.Rect, err error) {
+	err = e.inspect(func() error {
+		rect, err = e.scrolledRectangle()
+		return err
+	})
+	return rect, err
+}
+
+func (e Element) scrolledRectangle() (*dom.Rect, error) {
+	if err := e.ScrollIntoView(); err != nil {
+		return nil, err
+	}
+	return e.GetRectangle()
+}
+
 func (e Element) GetComputedStyle(style string) (string, error) {
 	v, err := e.CallFunction(functionGetComputedStyle, true, false, NewSingleCallArgument(style))
 	if err != nil {
@@ -354,3 +406,26 @@ func (e Element) stringArray(v *runtime.RemoteObject) ([]string, error) {
 	}
 	return options, nil
 }
+
+// CaptureScreenshot capture screenshot of the element area, scroll positions are restored if SetScrollRestore is on
+func (e Element) CaptureScreenshot(format string, quality int) (data []byte, err error) {
+	err = e.inspect(func() error {
+		rect, err := e.scrolledRectangle()
+		if err != nil {
+			return err
+		}
+		metric, err := e.frame.Session().GetLayoutMetrics()
+		if err != nil {
+			return err
+		}
+		data, err = e.frame.Session().CaptureScreenshot(format, quality, &page.Viewport{
+			X:      rect.X + metric.CssVisualViewport.PageX,
+			Y:      rect.Y + metric.CssVisualViewport.PageY,
+			Width:  rect.Width,
+			Height: rect.Height,
+			Scale:  1,
+		}, true, false)
+		return err
+	})
+	return data, err
+}
@@ -1,6 +1,7 @@
 package control
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/ecwid/control/mobile"
@@ -12,12 +13,38 @@ type Emulation struct {
 	s *Session
 }
 
+const (
+	MediaScreen = "screen"
+	MediaPrint  = "print"
+)
+
+// PrefersColorScheme media feature, value is "light" or "dark"
+func PrefersColorScheme(value string) *emulation.MediaFeature {
+	return &emulation.MediaFeature{Name: "prefers-color-scheme", Value: value}
+}
+
+// PrefersReducedMotion media feature, value is "reduce" or "no-preference"
+func PrefersReducedMotion(value string) *emulation.MediaFeature {
+	return &emulation.MediaFeature{Name: "prefers-reduced-motion", Value: value}
+}
+
+// PrefersContrast media feature, value is "more", "less", "custom" or "no-preference"
+func PrefersContrast(value string) *emulation.MediaFeature {
+	return &emulation.MediaFeature{Name: "prefers-contrast", Value: value}
+}
+
+// ForcedColors media feature, value is "active" or "none"
+func ForcedColors(value string) *emulation.MediaFeature {
+	return &emulation.MediaFeature{Name: "forced-colors", Value: value}
+}
+
 // SetDeviceMetricsOverride ...
 func (e Emulation) SetDeviceMetricsOverride(metrics emulation.SetDeviceMetricsOverrideArgs) error {
 	return emulation.SetDeviceMetricsOverride(e.s, metrics)
 }
 
-// SetUserAgentOverride ...
+// SetUserAgentOverride override user agent, Accept-Language, navigator.platform and user agent client hints
+// (Sec-CH-UA-* headers and navigator.userAgentData), metadata should be consistent with user agent string
 func (e Emulation) SetUserAgentOverride(userAgent, acceptLanguage, platform string, userAgentMetadata *common.UserAgentMetadata) error {
 	return emulation.SetUserAgentOverride(e.s, emulation.SetUserAgentOverrideArgs{
 		UserAgent:         userAgent,
@@ -53,13 +80,120 @@ func (e Emulation) SetDocumentCookieDisabled(disabled bool) error {
 	})
 }
 
-// Emulate emulate predefined device
+// EmulateMedia emulate CSS media type (MediaScreen, MediaPrint or empty to keep current type) and media features,
+// e.g. EmulateMedia("", PrefersColorScheme("dark")). Call without arguments resets emulation
+func (e Emulation) EmulateMedia(media string, features ...*emulation.MediaFeature) error {
+	return emulation.SetEmulatedMedia(e.s, emulation.SetEmulatedMediaArgs{Media: media, Features: features})
+}
+
+// SetTimezone override timezone of the page by IANA id (e.g. "Europe/Berlin"), empty id restores host timezone
+func (e Emulation) SetTimezone(timezoneID string) error {
+	return emulation.SetTimezoneOverride(e.s, emulation.SetTimezoneOverrideArgs{TimezoneId: timezoneID})
+}
+
+// SetLocale override ICU locale of the page (e.g. "de-DE") that affects Intl and date formatting,
+// empty locale restores host locale
+func (e Emulation) SetLocale(locale string) error {
+	return emulation.SetLocaleOverride(e.s, emulation.SetLocaleOverrideArgs{Locale: locale})
+}
+
+// SetIdleOverride override state of Idle Detection API: user activity and screen lock
+func (e Emulation) SetIdleOverride(userActive, screenUnlocked bool) error {
+	return emulation.SetIdleOverride(e.s, emulation.SetIdleOverrideArgs{
+		IsUserActive:     userActive,
+		IsScreenUnlocked: screenUnlocked,
+	})
+}
+
+// ClearIdleOverride restore real idle state
+func (e Emulation) ClearIdleOverride() error {
+	return emulation.ClearIdleOverride(e.s)
+}
+
+// SetPageScaleFactor set pinch-zoom scale of the visual viewport, layout is not changed
+func (e Emulation) SetPageScaleFactor(factor float64) error {
+	return emulation.SetPageScaleFactor(e.s, emulation.SetPageScaleFactorArgs{PageScaleFactor: factor})
+}
+
+// SetZoom emulate browser zoom (e.g. 0.75, 1.5): CSS viewport is divided and device pixel ratio is multiplied
+// by the factor, so media queries and layout behave like at the zoom level. It replaces device metrics override,
+// zoom 1 clears it
+func (s Session) SetZoom(factor float64) error {
+	if err := s.Emulation.ClearDeviceMetricsOverride(); err != nil {
+		return err
+	}
+	if factor == 1 {
+		return nil
+	}
+	val, err := s.Evaluate(`[window.innerWidth,window.innerHeight,window.devicePixelRatio]`, false, true)
+	if err != nil {
+		return err
+	}
+	var size, _ = val.([]interface{})
+	if len(size) != 3 {
+		return fmt.Errorf("unexpected viewport size %v", val)
+	}
+	width, _ := size[0].(float64)
+	height, _ := size[1].(float64)
+	dpr, _ := size[2].(float64)
+	return s.Emulation.SetDeviceMetricsOverride(emulation.SetDeviceMetricsOverrideArgs{
+		Width:             int(math.Round(width / factor)),
+		Height:            int(math.Round(height / factor)),
+		DeviceScaleFactor: dpr * factor,
+	})
+}
+
+// Emulate emulate predefined device: viewport, device scale factor, mobile flag, touch and user agent
 func (e Emulation) Emulate(device *mobile.Device) error {
-	device.Metrics.DontSetVisibleSize = true
-	if err := e.SetDeviceMetricsOverride(device.Metrics); err != nil {
+	var metrics = device.Metrics
+	metrics.DontSetVisibleSize = true
+	if err := e.SetDeviceMetricsOverride(metrics); err != nil {
+		return err
+	}
+	if err := e.SetTouchEmulation(device.Touch); err != nil {
 		return err
 	}
-	return e.SetUserAgentOverride(device.UserAgent, "", "", nil)
+	if device.UserAgent == "" {
+		return nil
+	}
+	return e.SetUserAgentOverride(device.UserAgent, "", "", device.Metadata)
+}
+
+// EmulateMobile emulate mobile viewport with device pixel ratio together with touch input (5 touch points),
+// it is reset by ClearDeviceMetricsOverride and SetTouchEmulation(false)
+func (e Emulation) EmulateMobile(width, height int, dpr float64) error {
+	var orientation = mobile.ScreenOrientationPortrait
+	if width > height {
+		orientation = mobile.ScreenOrientationLandscape
+	}
+	return e.Emulate(&mobile.Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             width,
+			Height:            height,
+			DeviceScaleFactor: dpr,
+			Mobile:            true,
+			ScreenOrientation: orientation,
+		},
+		Touch: true,
+	})
+}
+
+// SetTouchEmulation toggles touch input emulation (maxTouchPoints is 5) for mouse-less devices
+func (e Emulation) SetTouchEmulation(enabled bool) error {
+	var args = emulation.SetTouchEmulationEnabledArgs{Enabled: enabled}
+	if enabled {
+		args.MaxTouchPoints = 5
+	}
+	return emulation.SetTouchEmulationEnabled(e.s, args)
+}
+
+// EmulateDevice emulate device of mobile.Devices catalog by name (e.g. "iPhone X", "Pixel 5", "Desktop Full HD")
+func (s Session) EmulateDevice(name string) error {
+	device, ok := mobile.Devices[name]
+	if !ok {
+		return NoSuchDeviceError{Name: name}
+	}
+	return s.Emulation.Emulate(device)
 }
 
 func (e Emulation) FitZoomToWindow() error {
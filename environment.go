@@ -0,0 +1,97 @@
+package control
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Environment target environment of the suite (e.g. dev, staging, prod)
+type Environment struct {
+	Name    string
+	BaseURL string            // base of `~/path` urls
+	Headers map[string]string // sent with every request of the sessions
+	// Username and Password of HTTP authentication, they answer auth challenges of BaseURL origin only
+	Username string
+	Password string
+}
+
+type environments struct {
+	mx      sync.RWMutex
+	list    map[string]*Environment
+	current *Environment
+}
+
+func newEnvironments() *environments {
+	return &environments{list: map[string]*Environment{}}
+}
+
+// AddEnvironment register environment, the first one becomes current
+func (b BrowserContext) AddEnvironment(env Environment) {
+	b.environments.mx.Lock()
+	defer b.environments.mx.Unlock()
+	b.environments.list[env.Name] = &env
+	if b.environments.current == nil {
+		b.environments.current = &env
+	}
+}
+
+// UseEnvironment switch current environment, it's applied to the sessions created after the call
+func (b BrowserContext) UseEnvironment(name string) error {
+	b.environments.mx.Lock()
+	defer b.environments.mx.Unlock()
+	env, ok := b.environments.list[name]
+	if !ok {
+		return ErrNoEnvironment
+	}
+	b.environments.current = env
+	return nil
+}
+
+// Environment returns current environment or nil
+func (b BrowserContext) Environment() *Environment {
+	b.environments.mx.RLock()
+	defer b.environments.mx.RUnlock()
+	return b.environments.current
+}
+
+// ResolveURL resolves `~/path` url against base url of current environment, other urls are returned as is
+func (b BrowserContext) ResolveURL(url string) string {
+	if !strings.HasPrefix(url, "~/") {
+		return url
+	}
+	if env := b.Environment(); env != nil {
+		return strings.TrimSuffix(env.BaseURL, "/") + url[1:]
+	}
+	return url
+}
+
+// headers extra headers of the environment
+func (e Environment) headers() map[string]string {
+	var headers = map[string]string{}
+	for name, value := range e.Headers {
+		headers[name] = value
+	}
+	return headers
+}
+
+// origin scheme://host[:port] of BaseURL, empty if it's not absolute
+func (e Environment) origin() string {
+	u, err := url.Parse(e.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// useEnvironment apply credentials of the environment to the session
+func (s Session) useEnvironment(env *Environment) error {
+	if env.Username == "" && env.Password == "" {
+		return nil
+	}
+	var origin = env.origin()
+	if origin == "" {
+		return nil
+	}
+	return s.authenticateOrigin(origin, env.Username, env.Password)
+}
@@ -3,11 +3,13 @@ package control
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ecwid/control/protocol/common"
 
 	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
 )
 
 var (
@@ -18,8 +20,39 @@ var (
 	ErrDetachedFromTarget        = errors.New("detached from target")
 	ErrClickTimeout              = errors.New("no click registered")
 	ErrExecutionContextDestroyed = errors.New("execution context was destroyed")
+	ErrCreateTargetNotSupported  = errors.New("target creation is not supported by the browser")
+	ErrNoEnvironment             = errors.New("no such environment")
+	ErrNoOpenAPIServer           = errors.New("base url is not specified and OpenAPI document has no servers")
+	ErrNoDocuments               = errors.New("snapshot has no documents")
+	ErrPageError                 = errors.New("uncaught page exception")
+	ErrNotInitialized            = errors.New("browser context is not created by New")
 )
 
+// DomainUnavailableError method is not supported by the target (e.g. no Browser domain on Android WebView)
+type DomainUnavailableError struct {
+	Method string
+}
+
+func (e DomainUnavailableError) Error() string {
+	return fmt.Sprintf("domain of `%s` is not available on this target", e.Method)
+}
+
+const codeMethodNotFound = -32601
+
+func isMethodNotFound(err error) bool {
+	if v, ok := err.(*transport.Error); ok {
+		return v.Code == codeMethodNotFound
+	}
+	return false
+}
+
+func domainOf(method string) string {
+	if i := strings.Index(method, "."); i != -1 {
+		return method[:i]
+	}
+	return method
+}
+
 type ErrTargetCrashed target.TargetCrashed
 
 func (e ErrTargetCrashed) Error() string {
@@ -34,6 +67,30 @@ func (n NoSuchElementError) Error() string {
 	return fmt.Sprintf("no such element `%s`", n.Selector)
 }
 
+type NoSuchDeviceError struct {
+	Name string
+}
+
+func (e NoSuchDeviceError) Error() string {
+	return fmt.Sprintf("no such device `%s`", e.Name)
+}
+
+type UnknownKeyError struct {
+	Key string
+}
+
+func (e UnknownKeyError) Error() string {
+	return fmt.Sprintf("unknown key `%s`", e.Key)
+}
+
+type NoCheckpointError struct {
+	Name string
+}
+
+func (e NoCheckpointError) Error() string {
+	return fmt.Sprintf("no such checkpoint `%s`", e.Name)
+}
+
 type NoSuchFrameError struct {
 	id common.FrameId
 }
@@ -67,3 +124,41 @@ type ClickTargetOverlappedError struct {
 func (e ClickTargetOverlappedError) Error() string {
 	return fmt.Sprintf("click at target is overlapped by `%s`", e.outerHTML)
 }
+
+type NoSuchTextError struct {
+	Text       string
+	Candidates []string
+}
+
+func (n NoSuchTextError) Error() string {
+	if len(n.Candidates) == 0 {
+		return fmt.Sprintf("no element with text `%s`", n.Text)
+	}
+	return fmt.Sprintf("no element with text `%s`, near-miss candidates: `%s`", n.Text, strings.Join(n.Candidates, "`, `"))
+}
+
+type BackForwardCacheError struct {
+	Expected bool
+	Result   BackForwardCacheResult
+}
+
+func (e BackForwardCacheError) Error() string {
+	if !e.Expected {
+		return fmt.Sprintf("page `%s` was unexpectedly restored from back-forward cache", e.Result.URL)
+	}
+	var reasons = make([]string, len(e.Result.NotRestored))
+	for i, v := range e.Result.NotRestored {
+		reasons[i] = fmt.Sprintf("%s (%s)", v.Reason, v.Type)
+	}
+	return fmt.Sprintf("page `%s` was not restored from back-forward cache, reasons: %s", e.Result.URL, strings.Join(reasons, ", "))
+}
+
+// EventDecodeError params of the event don't match the protocol type of typed subscription
+type EventDecodeError struct {
+	Method string
+	Err    error
+}
+
+func (e EventDecodeError) Error() string {
+	return fmt.Sprintf("decode event `%s`: %s", e.Method, e.Err)
+}
@@ -0,0 +1,89 @@
+package control
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/runtime"
+)
+
+// PageError uncaught exception of the page
+type PageError struct {
+	runtime.ExceptionDetails
+	Time time.Time
+}
+
+func (e PageError) Error() string {
+	var description = e.Text
+	if e.Exception != nil && e.Exception.Description != "" {
+		description = e.Exception.Description
+	}
+	return fmt.Sprintf("uncaught page exception at %s:%d:%d: %s", e.Url, e.LineNumber, e.ColumnNumber, description)
+}
+
+type pageErrors struct {
+	mx       sync.Mutex
+	list     []PageError
+	failFast bool
+	occurred chan struct{} // closed on every new error
+}
+
+func newPageErrors() *pageErrors {
+	return &pageErrors{occurred: make(chan struct{})}
+}
+
+func (p *pageErrors) add(v runtime.ExceptionThrown) {
+	if v.ExceptionDetails == nil {
+		return
+	}
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.list = append(p.list, PageError{ExceptionDetails: *v.ExceptionDetails, Time: time.Now()})
+	close(p.occurred)
+	p.occurred = make(chan struct{})
+}
+
+// failFastC returns channel closed on the next page error in fail-fast mode, nil channel otherwise
+func (p *pageErrors) failFastC() <-chan struct{} {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if !p.failFast {
+		return nil
+	}
+	return p.occurred
+}
+
+// last returns the latest page error, ErrPageError if it was already drained
+func (p *pageErrors) last() error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if len(p.list) == 0 {
+		return ErrPageError
+	}
+	return p.list[len(p.list)-1]
+}
+
+// PageErrors returns uncaught exceptions of the page collected since the last drain
+func (s Session) PageErrors() []PageError {
+	s.pageErrors.mx.Lock()
+	defer s.pageErrors.mx.Unlock()
+	return append([]PageError{}, s.pageErrors.list...)
+}
+
+// DrainPageErrors returns collected uncaught exceptions and forgets them
+func (s Session) DrainPageErrors() []PageError {
+	s.pageErrors.mx.Lock()
+	defer s.pageErrors.mx.Unlock()
+	var list = s.pageErrors.list
+	s.pageErrors.list = nil
+	return list
+}
+
+// SetFailOnPageError in fail-fast mode Wait, Future.Get and Assert fail with PageError
+// as soon as uncaught exception appears on the page
+func (s Session) SetFailOnPageError(enabled bool) {
+	s.pageErrors.mx.Lock()
+	defer s.pageErrors.mx.Unlock()
+	s.pageErrors.failFast = enabled
+}
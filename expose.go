@@ -0,0 +1,140 @@
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ecwid/control/protocol/runtime"
+	"github.com/ecwid/control/transport"
+)
+
+// ExposedFunction is a Go callback exposed to the page, args are JSON values passed by the page
+type ExposedFunction func(args []json.RawMessage) (interface{}, error)
+
+type exposeCall struct {
+	Name string            `json:"name"`
+	Seq  int64             `json:"seq"`
+	Args []json.RawMessage `json:"args"`
+}
+
+// ExposeFunction installs window[name] function into every frame of the page and its out-of-process iframes
+// (current and future documents). Calling it from the page returns Promise resolved with JSON result of the function
+func (s Session) ExposeFunction(name string, function ExposedFunction) (cancel func(), err error) {
+	if cancel, err = s.exposeFunction(name, function); err != nil {
+		return nil, err
+	}
+	var (
+		mx      sync.Mutex
+		cancels = []func(){cancel}
+	)
+	var child = func(c *Session) error {
+		cc, err := c.ExposeFunction(name, function)
+		if err != nil {
+			if c.State().IsTerminal() {
+				return nil
+			}
+			return err
+		}
+		mx.Lock()
+		cancels = append(cancels, cc)
+		mx.Unlock()
+		return nil
+	}
+	// function is missing in iframe attached later if it fails to install there
+	stop := s.onOOPIFAttached(func(c *Session) { _ = child(c) })
+	cancel = func() {
+		stop()
+		mx.Lock()
+		var rest = cancels
+		cancels = nil
+		mx.Unlock()
+		for _, c := range rest {
+			c()
+		}
+	}
+	s.oopifs.Range(func(_, value interface{}) bool {
+		err = child(value.(*Session))
+		return err == nil
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return cancel, nil
+}
+
+// exposeFunction install the function into frames of the session only
+func (s Session) exposeFunction(name string, function ExposedFunction) (cancel func(), err error) {
+	if err = runtime.AddBinding(s, runtime.AddBindingArgs{Name: bindExpose}); err != nil {
+		return nil, err
+	}
+	script := fmt.Sprintf(`(%s)(%q)`, functionExpose, name)
+	identifier, err := s.AddScriptToEvaluateOnNewDocument(script)
+	if err != nil {
+		return nil, err
+	}
+	unsubscribe := s.Subscribe("Runtime.bindingCalled", func(value transport.Event) {
+		var (
+			bindingCalled = runtime.BindingCalled{}
+			call          = exposeCall{}
+		)
+		if err1 := json.Unmarshal(value.Params, &bindingCalled); err1 != nil || bindingCalled.Name != bindExpose {
+			return
+		}
+		if err1 := json.Unmarshal([]byte(bindingCalled.Payload), &call); err1 != nil || call.Name != name {
+			return
+		}
+		go s.deliverExposeResult(bindingCalled.ExecutionContextId, call, function)
+	})
+	cancel = func() {
+		unsubscribe()
+		_ = s.RemoveScriptToEvaluateOnNewDocument(identifier)
+	}
+	var contexts []runtime.ExecutionContextId
+	s.executions.Range(func(_, value interface{}) bool {
+		contexts = append(contexts, value.(runtime.ExecutionContextId))
+		return true
+	})
+	for _, cid := range contexts {
+		if _, err = s.callFunctionIn(cid, functionExpose, NewSingleCallArgument(name)); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	return cancel, nil
+}
+
+func (s Session) deliverExposeResult(cid runtime.ExecutionContextId, call exposeCall, function ExposedFunction) {
+	var (
+		value, err = function(call.Args)
+		errText    = ""
+	)
+	if err != nil {
+		errText = err.Error()
+		value = nil
+	}
+	_, _ = s.callFunctionIn(cid, functionExposeDeliver, []*runtime.CallArgument{
+		{Value: call.Name},
+		{Value: call.Seq},
+		{Value: value},
+		{Value: errText},
+	})
+}
+
+func (s Session) callFunctionIn(cid runtime.ExecutionContextId, function string, args []*runtime.CallArgument) (*runtime.RemoteObject, error) {
+	val, err := runtime.CallFunctionOn(s, runtime.CallFunctionOnArgs{
+		FunctionDeclaration: function,
+		ExecutionContextId:  cid,
+		Arguments:           args,
+		AwaitPromise:        true,
+		ReturnByValue:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	return val.Result, nil
+}
@@ -0,0 +1,77 @@
+package control
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+func TestExposeFunctionOOPIF(t *testing.T) {
+	var childCall = func(id int, sessionID, method string) cdptest.Message {
+		return cdptest.Message{Direction: transport.DirectionSend, Method: method, Data: json.RawMessage(`{"id":` + strconv.Itoa(id) + `,"sessionId":"` + sessionID + `","method":"` + method + `"}`)}
+	}
+	var childReply = func(id int, sessionID, result string) cdptest.Message {
+		return cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(`{"id":` + strconv.Itoa(id) + `,"sessionId":"` + sessionID + `","result":` + result + `}`)}
+	}
+	s, srv, log := testSession(t,
+		call(1, "Runtime.addBinding", ""),
+		reply(1, `{}`),
+		call(2, "Page.addScriptToEvaluateOnNewDocument", ""),
+		reply(2, `{"identifier":"1"}`),
+		childCall(3, "C1", "Runtime.addBinding"),
+		childReply(3, "C1", `{}`),
+		childCall(4, "C1", "Page.addScriptToEvaluateOnNewDocument"),
+		childReply(4, "C1", `{"identifier":"2"}`),
+		childCall(5, "C2", "Runtime.addBinding"),
+		childReply(5, "C2", `{}`),
+		childCall(6, "C2", "Page.addScriptToEvaluateOnNewDocument"),
+		childReply(6, "C2", `{"identifier":"3"}`),
+		call(7, "Page.removeScriptToEvaluateOnNewDocument", ""),
+		reply(7, `{}`),
+		childCall(8, "C1", "Page.removeScriptToEvaluateOnNewDocument"),
+		childReply(8, "C1", `{}`),
+		childCall(9, "C2", "Page.removeScriptToEvaluateOnNewDocument"),
+		childReply(9, "C2", `{}`),
+	)
+	var attached = s.browser.newSession("F1", "C1")
+	attached.lifecycleState.set(StateReady, nil)
+	s.oopifs.Store(common.FrameId(attached.tid), attached)
+	cancel, err := s.ExposeFunction("fn", func([]json.RawMessage) (interface{}, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// iframe attached after the function is exposed
+	var later = s.browser.newSession("F2", "C2")
+	later.lifecycleState.set(StateReady, nil)
+	s.oopifHooks.Range(func(_, hook interface{}) bool {
+		hook.(func(*Session))(later)
+		return true
+	})
+	cancel()
+	played(t, srv)
+
+	log.mx.Lock()
+	messages, err := cdptest.ParseWireLog(bytes.NewReader(log.buf.Bytes()))
+	log.mx.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var installed = map[string]int{}
+	for _, m := range messages {
+		var v = struct{ SessionID string }{}
+		_ = json.Unmarshal(m.Data, &v)
+		if m.Direction == transport.DirectionSend && m.Method == "Runtime.addBinding" {
+			installed[v.SessionID]++
+		}
+	}
+	for _, sessionID := range []string{testSessionID, "C1", "C2"} {
+		if installed[sessionID] != 1 {
+			t.Errorf("binding is not installed in session %s", sessionID)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/page"
+)
+
+// AddOriginTrialTokens register origin trial tokens for every new document of the page
+// by injecting <meta http-equiv="origin-trial"> tags into the head
+func (s Session) AddOriginTrialTokens(tokens ...string) (page.ScriptIdentifier, error) {
+	b, err := json.Marshal(tokens)
+	if err != nil {
+		return "", err
+	}
+	return s.AddScriptToEvaluateOnNewDocument(fmt.Sprintf(functionOriginTrialMeta, b))
+}
+
+// AddOriginTrialHeader register origin trial tokens by adding Origin-Trial header to the responses
+// of documents matched by urlPattern (e.g. https://example.com/*)
+func (s Session) AddOriginTrialHeader(urlPattern string, tokens ...string) (cancel func(), err error) {
+	return s.Intercept(fetch.RequestPattern{UrlPattern: urlPattern, ResourceType: "Document", RequestStage: StageResponse}, func(route *Route) {
+		body, err := route.ResponseBody()
+		if err != nil {
+			return
+		}
+		var headers = route.ResponseHeaders
+		for _, token := range tokens {
+			headers = append(headers, &fetch.HeaderEntry{Name: "Origin-Trial", Value: token})
+		}
+		_ = route.FulfillWith(fetch.FulfillRequestArgs{
+			ResponseCode:    route.ResponseStatusCode,
+			ResponseHeaders: headers,
+			Body:            body,
+		})
+	})
+}
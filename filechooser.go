@@ -0,0 +1,56 @@
+package control
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ecwid/control/protocol/dom"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+// FileChooser intercepted file chooser dialog
+type FileChooser struct {
+	page.FileChooserOpened
+	session *Session
+}
+
+// IsMultiple true if chooser accepts multiple files
+func (f FileChooser) IsMultiple() bool {
+	return f.Mode == "selectMultiple"
+}
+
+// SetFiles satisfy file chooser with files
+func (f FileChooser) SetFiles(files ...string) error {
+	return dom.SetFileInputFiles(f.session, dom.SetFileInputFilesArgs{
+		Files:         files,
+		BackendNodeId: f.BackendNodeId,
+	})
+}
+
+// ExpectFileChooser performs action (e.g. click on custom upload button) and intercepts opened file chooser dialog
+func (s Session) ExpectFileChooser(action func() error, timeout time.Duration) (*FileChooser, error) {
+	future := s.Observe("Page.fileChooserOpened", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		var v = page.FileChooserOpened{}
+		if err := json.Unmarshal(value.Params, &v); err != nil {
+			reject(err)
+			return
+		}
+		resolve(&FileChooser{FileChooserOpened: v, session: &s})
+	})
+	defer future.Cancel()
+	if err := page.SetInterceptFileChooserDialog(s, page.SetInterceptFileChooserDialogArgs{Enabled: true}); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = page.SetInterceptFileChooserDialog(s, page.SetInterceptFileChooserDialogArgs{Enabled: false})
+	}()
+	if err := action(); err != nil {
+		return nil, err
+	}
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return val.(*FileChooser), nil
+}
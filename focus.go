@@ -0,0 +1,99 @@
+package control
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ecwid/control/protocol/emulation"
+	"github.com/ecwid/control/protocol/target"
+)
+
+// focusCoordinator window focus shared by headful sessions of one display, sessions waiting
+// for focus are served in order of their arrival
+type focusCoordinator struct {
+	enabled int32
+	sem     chan struct{}
+	mx      sync.Mutex
+	owner   target.TargetID // session holding focus
+	active  target.TargetID // last activated target
+}
+
+func newFocusCoordinator() *focusCoordinator {
+	return &focusCoordinator{sem: make(chan struct{}, 1)}
+}
+
+// SetFocusCoordination if enabled then input actions of elements (click, hover, typing) take focus of the window
+// in turn and activate their page before the action, other calls of the sessions run in background
+func (b BrowserContext) SetFocusCoordination(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&b.focus.enabled, v)
+}
+
+// acquire wait for window focus and activate the page if focus was held by another page
+func (f *focusCoordinator) acquire(s Session) (release func(), err error) {
+	var tid = s.current().tid // page that replaced prerendered one holds the focus
+	f.mx.Lock()
+	var owned = f.owner == tid
+	f.mx.Unlock()
+	if owned {
+		return func() {}, nil
+	}
+	select {
+	case f.sem <- struct{}{}:
+	case <-s.context.Done():
+		return nil, s.context.Err()
+	}
+	f.mx.Lock()
+	f.owner = tid
+	var activate = f.active != tid
+	f.active = tid
+	f.mx.Unlock()
+	release = func() {
+		f.mx.Lock()
+		f.owner = ""
+		f.mx.Unlock()
+		<-f.sem
+	}
+	if activate {
+		if err = s.Activate(); err != nil {
+			f.mx.Lock()
+			f.active = ""
+			f.mx.Unlock()
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// WithFocus activate the page, waiting until focus is released by other sessions, and hold focus while action runs
+func (s Session) WithFocus(action func() error) error {
+	release, err := s.browser.focus.acquire(s)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return action()
+}
+
+// focusActions take focus for input action if coordination is enabled, activation errors are ignored
+// and action is performed in background
+func (s Session) focusActions() func() {
+	if atomic.LoadInt32(&s.browser.focus.enabled) == 0 {
+		return func() {}
+	}
+	release, err := s.browser.focus.acquire(s)
+	if err != nil {
+		return func() {}
+	}
+	return release
+}
+
+// SetFocusEmulation emulate focused page, so the page that runs in background behaves
+// as focused one (focus events, :focus styles, document.hasFocus())
+func (s Session) SetFocusEmulation(enabled bool) error {
+	return emulation.SetFocusEmulationEnabled(s, emulation.SetFocusEmulationEnabledArgs{Enabled: enabled})
+}
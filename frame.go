@@ -1,10 +1,12 @@
 package control
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ecwid/control/protocol/common"
@@ -41,6 +43,21 @@ func (f Frame) ID() common.FrameId {
 	return f.id
 }
 
+// lockActions serialize input actions targeting the frame, returns unlock function. Locks are taken
+// in order: frame, window focus, input of the session (the one Actions.Perform and gestures hold)
+func (f Frame) lockActions() func() {
+	val, _ := f.session.actions.LoadOrStore(f.id, &sync.Mutex{})
+	mx := val.(*sync.Mutex)
+	mx.Lock()
+	release := f.session.focusActions()
+	f.session.Input.mx.Lock()
+	return func() {
+		f.session.Input.mx.Unlock()
+		release()
+		mx.Unlock()
+	}
+}
+
 func (f Frame) Call(method string, send, recv interface{}) error {
 	return f.Session().Call(method, send, recv)
 }
@@ -62,7 +79,14 @@ func (f Frame) GetLifecycleEvent(event LifecycleEventType) Future {
 	})
 }
 
+// Navigate runs seeds deferred by SeedBeforeNavigation, expands seed placeholders of url
+// and resolves `~/path` url against current environment before navigation
 func (f Frame) Navigate(url string, eventType LifecycleEventType, timeout time.Duration) error {
+	if err := f.session.runPendingSeeds(); err != nil {
+		return err
+	}
+	url = f.session.browser.ResolveURL(f.session.Expand(url))
+	f.session.slowDown()
 	future := f.GetLifecycleEvent(eventType)
 	defer future.Cancel()
 	nav, err := page.Navigate(f, page.NavigateArgs{
@@ -79,13 +103,14 @@ func (f Frame) Navigate(url string, eventType LifecycleEventType, timeout time.D
 	if nav.LoaderId == "" {
 		return ErrAlreadyNavigated
 	}
-	_, err = future.Get(timeout)
+	_, err = future.get(timeout, nil)
 	return err
 
 }
 
 // Reload refresh current page
 func (f Frame) Reload(ignoreCache bool, scriptToEvaluateOnLoad string, eventType LifecycleEventType, timeout time.Duration) error {
+	f.session.slowDown()
 	future := f.GetLifecycleEvent(eventType)
 	defer future.Cancel()
 	err := page.Reload(f, page.ReloadArgs{
@@ -95,10 +120,16 @@ func (f Frame) Reload(ignoreCache bool, scriptToEvaluateOnLoad string, eventType
 	if err != nil {
 		return err
 	}
-	_, err = future.Get(timeout)
+	_, err = future.get(timeout, nil)
 	return err
 }
 
+// SetContent replace document of the frame with given html and wait for the lifecycle event
+func (f Frame) SetContent(html string, eventType LifecycleEventType, timeout time.Duration) error {
+	url := "data:text/html;charset=utf-8;base64," + base64.StdEncoding.EncodeToString([]byte(html))
+	return f.Navigate(url, eventType, timeout)
+}
+
 func safeSelector(v string) string {
 	v = strings.TrimSpace(v)
 	v = strings.ReplaceAll(v, `"`, `\"`)
@@ -124,7 +155,12 @@ func (f Frame) QuerySelector(selector string) (*Element, error) {
 	if object.ObjectId == "" {
 		return nil, NoSuchElementError{Selector: selector}
 	}
-	return f.constructElement(object)
+	el, err := f.constructElement(object)
+	if err != nil {
+		return nil, err
+	}
+	el.selector = selector
+	return el, nil
 }
 
 func (f Frame) QuerySelectorAll(selector string) ([]*Element, error) {
@@ -149,6 +185,7 @@ func (f Frame) QuerySelectorAll(selector string) ([]*Element, error) {
 		if err1 != nil {
 			return nil, err1
 		}
+		el.selector = fmt.Sprintf("%s[%s]", selector, d.Name)
 		list = append(list, el)
 	}
 	return list, nil
@@ -210,6 +247,7 @@ func (f Frame) NavigateHistory(delta int) error {
 	}
 	move := val.CurrentIndex + delta
 	if move >= 0 && move < len(val.Entries) {
+		f.session.slowDown()
 		return page.NavigateToHistoryEntry(f, page.NavigateToHistoryEntryArgs{
 			EntryId: val.Entries[move].Id,
 		})
@@ -228,3 +266,24 @@ func (f Frame) RequestDOMIdle(threshold, timeout time.Duration) error {
 	}
 	return err
 }
+
+// PollingRAF polling predicate of WaitForFunction on every requestAnimationFrame
+const PollingRAF time.Duration = 0
+
+// WaitForFunction evaluate predicate expression on every animation frame (polling = PollingRAF) or with interval
+// until it returns truthy value, returns the value
+func (f Frame) WaitForFunction(expression string, polling, timeout time.Duration) (interface{}, error) {
+	var next = "requestAnimationFrame(f)"
+	if polling > PollingRAF {
+		next = fmt.Sprintf("setTimeout(f,%d)", polling.Milliseconds())
+	}
+	script := fmt.Sprintf(functionWaitFor, expression, timeout.Milliseconds(), next)
+	val, err := f.Evaluate(script, true, true)
+	switch v := err.(type) {
+	case RuntimeError:
+		if msg, _ := v.Exception.Value.(string); msg == "timeout" {
+			return nil, FutureTimeoutError{timeout: timeout}
+		}
+	}
+	return val, err
+}
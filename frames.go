@@ -0,0 +1,114 @@
+package control
+
+import (
+	"encoding/json"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+type FrameEventType string
+
+const (
+	FrameEventAttached  FrameEventType = "attached"
+	FrameEventNavigated FrameEventType = "navigated"
+	FrameEventDetached  FrameEventType = "detached"
+)
+
+// FrameEvent typed frame lifecycle event
+type FrameEvent struct {
+	Type     FrameEventType
+	Frame    *Frame
+	ParentID common.FrameId // attached only
+	URL      string         // navigated only
+}
+
+// Frames returns all frames of the page, main frame is the first one
+func (s Session) Frames() ([]*Frame, error) {
+	val, err := page.GetFrameTree(s)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		frames []*Frame
+		walk   func(tree *page.FrameTree)
+	)
+	walk = func(tree *page.FrameTree) {
+		frames = append(frames, s.frame(tree.Frame.Id))
+		for _, child := range tree.ChildFrames {
+			walk(child)
+		}
+	}
+	walk(val.FrameTree)
+	return frames, nil
+}
+
+func findFrameTree(tree *page.FrameTree, id common.FrameId) *page.FrameTree {
+	if tree.Frame.Id == id {
+		return tree
+	}
+	for _, child := range tree.ChildFrames {
+		if t := findFrameTree(child, id); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+func (f Frame) frameTree() (*page.FrameTree, error) {
+	val, err := page.GetFrameTree(f)
+	if err != nil {
+		return nil, err
+	}
+	if tree := findFrameTree(val.FrameTree, f.id); tree != nil {
+		return tree, nil
+	}
+	return nil, NoSuchFrameError{id: f.id}
+}
+
+// ChildFrames returns direct child frames of the frame
+func (f Frame) ChildFrames() ([]*Frame, error) {
+	tree, err := f.frameTree()
+	if err != nil {
+		return nil, err
+	}
+	var frames []*Frame
+	for _, child := range tree.ChildFrames {
+		frames = append(frames, f.session.frame(child.Frame.Id))
+	}
+	return frames, nil
+}
+
+// eventFrame resolve frame of the event as Frame does, frame that has no execution context yet
+// (e.g. just attached) is routed to the session that owns it
+func (s Session) eventFrame(id common.FrameId) *Frame {
+	if frame, err := s.Frame(id); err == nil {
+		return frame
+	}
+	return s.current().frame(id)
+}
+
+// OnFrameEvent subscribe on frame attached, navigated and detached events
+func (s Session) OnFrameEvent(handler func(FrameEvent)) (cancel func()) {
+	return s.Subscribe("*", func(value transport.Event) {
+		switch value.Method {
+		case "Page.frameAttached":
+			var v = page.FrameAttached{}
+			if err := json.Unmarshal(value.Params, &v); err == nil {
+				handler(FrameEvent{Type: FrameEventAttached, Frame: s.eventFrame(v.FrameId), ParentID: v.ParentFrameId})
+			}
+		case "Page.frameNavigated":
+			var v = page.FrameNavigated{}
+			if err := json.Unmarshal(value.Params, &v); err == nil {
+				handler(FrameEvent{Type: FrameEventNavigated, Frame: s.eventFrame(v.Frame.Id), URL: v.Frame.Url})
+			}
+		case "Page.frameDetached":
+			var v = page.FrameDetached{}
+			if err := json.Unmarshal(value.Params, &v); err == nil {
+				// frame is gone and can't be resolved
+				handler(FrameEvent{Type: FrameEventDetached, Frame: &Frame{id: v.FrameId, session: &s}})
+			}
+		}
+	})
+}
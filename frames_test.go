@@ -0,0 +1,47 @@
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+)
+
+func TestOnFrameEventRouting(t *testing.T) {
+	s, srv, _ := testSession(t,
+		call(1, "Page.enable", ""),
+		reply(1, `{}`),
+		event("Page.frameAttached", `{"frameId":"OOPIF","parentFrameId":"`+testTargetID+`"}`),
+		event("Page.frameNavigated", `{"frame":{"id":"OOPIF","loaderId":"L","url":"https://example.com/","securityOrigin":"","mimeType":"text/html"}}`),
+		event("Page.frameDetached", `{"frameId":"OOPIF"}`),
+	)
+	var child = s.browser.newSession("OOPIF", "C")
+	s.oopifs.Store(common.FrameId("OOPIF"), child)
+	var events = make(chan FrameEvent, 3)
+	defer s.OnFrameEvent(func(e FrameEvent) { events <- e })()
+	if err := s.Call("Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	var expect = []struct {
+		kind    FrameEventType
+		session *Session
+	}{
+		{FrameEventAttached, child}, // out-of-process iframe is routed to its own session
+		{FrameEventNavigated, child},
+		{FrameEventDetached, nil}, // detached frame is not resolved
+	}
+	for _, e := range expect {
+		select {
+		case got := <-events:
+			if got.Type != e.kind || got.Frame.ID() != "OOPIF" {
+				t.Fatalf("expected %v of OOPIF, got %v of %s", e.kind, got.Type, got.Frame.ID())
+			}
+			if e.session != nil && got.Frame.Session().id != e.session.id {
+				t.Fatalf("%v: frame is not routed to session of the iframe", e.kind)
+			}
+		case <-time.After(time.Second * 2):
+			t.Fatalf("no %v event", e.kind)
+		}
+	}
+	played(t, srv)
+}
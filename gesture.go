@@ -0,0 +1,65 @@
+package control
+
+import (
+	"time"
+
+	"github.com/ecwid/control/protocol/input"
+)
+
+const (
+	gestureFrame       = time.Millisecond * 16 // interval between touch moves
+	pinchDuration      = time.Millisecond * 300
+	pinchInitialRadius = 50.0 // distance of fingers from the center at the start of pinch
+)
+
+func (i Input) touch(kind string, points ...Point) error {
+	var list = make([]*input.TouchPoint, len(points))
+	for n, p := range points {
+		list[n] = &input.TouchPoint{X: p.X, Y: p.Y, Id: float64(n)}
+	}
+	return input.DispatchTouchEvent(i.s, input.DispatchTouchEventArgs{Type: kind, TouchPoints: list})
+}
+
+// gesture moves touch points from start to end positions during duration. Touch must be enabled,
+// see Emulation.SetTouchEmulation
+func (i Input) gesture(start, end []Point, duration time.Duration) error {
+	i.s.slowDown()
+	i.mx.Lock()
+	defer i.mx.Unlock()
+	if err := i.touch("touchStart", start...); err != nil {
+		return err
+	}
+	var steps = int(duration / gestureFrame)
+	if steps < 1 {
+		steps = 1
+	}
+	var current = make([]Point, len(start))
+	for n := 1; n <= steps; n++ {
+		i.s.Clock().Sleep(duration / time.Duration(steps))
+		var t = float64(n) / float64(steps)
+		for k := range start {
+			current[k] = Point{X: start[k].X + (end[k].X-start[k].X)*t, Y: start[k].Y + (end[k].Y-start[k].Y)*t}
+		}
+		if err := i.touch("touchMove", current...); err != nil {
+			_ = i.touch("touchCancel")
+			return err
+		}
+	}
+	return i.touch("touchEnd")
+}
+
+// Swipe swipe one finger from the point to the point of the viewport during duration
+func (i Input) Swipe(from, to Point, duration time.Duration) error {
+	return i.gesture([]Point{from}, []Point{to}, duration)
+}
+
+// Pinch pinch two fingers around the center, scale > 1 spreads fingers apart (zoom in) and scale < 1 brings them together
+func (i Input) Pinch(center Point, scale float64) error {
+	var (
+		r0    = pinchInitialRadius
+		r1    = pinchInitialRadius * scale
+		start = []Point{{X: center.X - r0, Y: center.Y}, {X: center.X + r0, Y: center.Y}}
+		end   = []Point{{X: center.X - r1, Y: center.Y}, {X: center.X + r1, Y: center.Y}}
+	)
+	return i.gesture(start, end, pinchDuration)
+}
@@ -0,0 +1,145 @@
+package control
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+const (
+	GraphQLQuery        = "query"
+	GraphQLMutation     = "mutation"
+	GraphQLSubscription = "subscription"
+)
+
+// GraphQLOperation operation of GraphQL request
+type GraphQLOperation struct {
+	Type          string // query, mutation or subscription
+	OperationName string
+	Query         string
+	Variables     map[string]interface{}
+}
+
+var graphQLDefinition = regexp.MustCompile(`^\s*(?:#[^\n]*\n\s*)*(query|mutation|subscription)?\s*([_A-Za-z][_0-9A-Za-z]*)?`)
+
+type graphQLPayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func newGraphQLOperation(p graphQLPayload) GraphQLOperation {
+	var op = GraphQLOperation{Type: GraphQLQuery, OperationName: p.OperationName, Query: p.Query, Variables: p.Variables}
+	// shorthand "{ ... }" is an anonymous query
+	if m := graphQLDefinition.FindStringSubmatch(p.Query); m != nil {
+		if m[1] != "" {
+			op.Type = m[1]
+		}
+		if op.OperationName == "" && m[1] != "" {
+			op.OperationName = m[2]
+		}
+	}
+	return op
+}
+
+// ParseGraphQL parse operations of GraphQL request: JSON body of POST request (single or batched operations)
+// or query parameters of GET request
+func ParseGraphQL(method, rawURL, body string) ([]GraphQLOperation, error) {
+	if method == http.MethodGet {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			q = u.Query()
+			p = graphQLPayload{Query: q.Get("query"), OperationName: q.Get("operationName")}
+		)
+		if v := q.Get("variables"); v != "" {
+			if err = json.Unmarshal([]byte(v), &p.Variables); err != nil {
+				return nil, err
+			}
+		}
+		if p.Query == "" {
+			return nil, nil
+		}
+		return []GraphQLOperation{newGraphQLOperation(p)}, nil
+	}
+	var payloads []graphQLPayload
+	if body = strings.TrimSpace(body); strings.HasPrefix(body, "[") {
+		if err := json.Unmarshal([]byte(body), &payloads); err != nil {
+			return nil, err
+		}
+	} else {
+		var p graphQLPayload
+		if err := json.Unmarshal([]byte(body), &p); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	var ops []GraphQLOperation
+	for _, p := range payloads {
+		if p.Query != "" || p.OperationName != "" { // persisted queries are sent without query
+			ops = append(ops, newGraphQLOperation(p))
+		}
+	}
+	return ops, nil
+}
+
+// GraphQL returns GraphQL operations of the request, nil if it's not a GraphQL request
+func (r Request) GraphQL() ([]GraphQLOperation, error) {
+	body, err := r.GetPostData()
+	if err != nil {
+		return nil, err
+	}
+	return ParseGraphQL(r.Method, r.URL, body)
+}
+
+// GraphQL returns GraphQL operations of the intercepted request, nil if it's not a GraphQL request
+func (r Route) GraphQL() ([]GraphQLOperation, error) {
+	var body = r.Request.PostData
+	if r.Request.HasPostData && body == "" {
+		var err error
+		if body, err = r.session.Network.GetRequestPostData(network.RequestId(r.NetworkId)); err != nil {
+			return nil, err
+		}
+	}
+	return ParseGraphQL(r.Request.Method, r.Request.Url, body)
+}
+
+// matchGraphQL true if any operation has given type (any if empty) and name
+func matchGraphQL(ops []GraphQLOperation, opType, operationName string) bool {
+	for _, op := range ops {
+		if (opType == "" || op.Type == opType) && op.OperationName == operationName {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGraphQL matches GraphQL requests by operation type (any if empty) and name,
+// e.g. MatchGraphQL(GraphQLMutation, "AddToCart"). Matcher runs in the event handler and uses post data
+// of the notification only, bodies longer than the limit of Network.SetMaxPostDataSize are not matched
+func MatchGraphQL(opType, operationName string) RequestMatcher {
+	return func(r *Request) bool {
+		if r.HasPostData && r.PostData == "" {
+			return false
+		}
+		ops, err := ParseGraphQL(r.Method, r.URL, r.PostData)
+		return err == nil && matchGraphQL(ops, opType, operationName)
+	}
+}
+
+// InterceptGraphQL pass GraphQL requests to urlPattern (all if empty) with operation of given type
+// (any if empty) and name to the handler
+func (s Session) InterceptGraphQL(urlPattern, opType, operationName string, handler RouteHandler) (cancel func(), err error) {
+	return s.Intercept(fetch.RequestPattern{UrlPattern: urlPattern}, func(route *Route) {
+		if ops, err := route.GraphQL(); err == nil && matchGraphQL(ops, opType, operationName) {
+			handler(route)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package control
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseGraphQL(t *testing.T) {
+	var cases = []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		expect []GraphQLOperation
+		fails  bool
+	}{
+		{
+			name:   "named query",
+			method: "POST",
+			body:   `{"query":"query GetCart($id: ID!) { cart(id: $id) { total } }","variables":{"id":"1"}}`,
+			expect: []GraphQLOperation{{Type: GraphQLQuery, OperationName: "GetCart", Query: "query GetCart($id: ID!) { cart(id: $id) { total } }", Variables: map[string]interface{}{"id": "1"}}},
+		},
+		{
+			name:   "operation name of payload wins",
+			method: "POST",
+			body:   `{"query":"mutation AddItem { add }","operationName":"AddToCart"}`,
+			expect: []GraphQLOperation{{Type: GraphQLMutation, OperationName: "AddToCart", Query: "mutation AddItem { add }"}},
+		},
+		{
+			name:   "shorthand anonymous query",
+			method: "POST",
+			body:   ` {"query":"{ me { id } }"} `,
+			expect: []GraphQLOperation{{Type: GraphQLQuery, Query: "{ me { id } }"}},
+		},
+		{
+			name:   "anonymous operation with variables",
+			method: "POST",
+			body:   `{"query":"subscription ($id: ID) { updated(id: $id) }"}`,
+			expect: []GraphQLOperation{{Type: GraphQLSubscription, Query: "subscription ($id: ID) { updated(id: $id) }"}},
+		},
+		{
+			name:   "leading comments",
+			method: "POST",
+			body:   `{"query":"# cart\n  # page\n mutation Checkout { checkout }"}`,
+			expect: []GraphQLOperation{{Type: GraphQLMutation, OperationName: "Checkout", Query: "# cart\n  # page\n mutation Checkout { checkout }"}},
+		},
+		{
+			name:   "batched operations and persisted query",
+			method: "POST",
+			body:   `[{"query":"query A { a }"},{"operationName":"B"},{"variables":{}}]`,
+			expect: []GraphQLOperation{
+				{Type: GraphQLQuery, OperationName: "A", Query: "query A { a }"},
+				{Type: GraphQLQuery, OperationName: "B"},
+			},
+		},
+		{
+			name:   "GET query parameters",
+			method: "GET",
+			url:    "https://example.com/graphql?query=" + url.QueryEscape("query Me { me }") + "&variables=" + url.QueryEscape(`{"a":1}`),
+			expect: []GraphQLOperation{{Type: GraphQLQuery, OperationName: "Me", Query: "query Me { me }", Variables: map[string]interface{}{"a": 1.0}}},
+		},
+		{
+			name:   "GET without query",
+			method: "GET",
+			url:    "https://example.com/graphql?x=1",
+		},
+		{
+			name:   "GET with malformed variables",
+			method: "GET",
+			url:    "https://example.com/graphql?query=%7Bme%7D&variables=%7B",
+			fails:  true,
+		},
+		{
+			name:   "not JSON body",
+			method: "POST",
+			body:   "query=1",
+			fails:  true,
+		},
+	}
+	for _, c := range cases {
+		ops, err := ParseGraphQL(c.method, c.url, c.body)
+		if (err != nil) != c.fails {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(ops, c.expect) {
+			t.Errorf("%s: expected %+v, got %+v", c.name, c.expect, ops)
+		}
+	}
+}
+
+func TestMatchGraphQL(t *testing.T) {
+	var cases = []struct {
+		request *Request
+		opType  string
+		name    string
+		match   bool
+	}{
+		{&Request{Method: "POST", HasPostData: true, PostData: `{"query":"mutation AddToCart { add }"}`}, GraphQLMutation, "AddToCart", true},
+		{&Request{Method: "POST", HasPostData: true, PostData: `{"query":"mutation AddToCart { add }"}`}, "", "AddToCart", true},
+		{&Request{Method: "POST", HasPostData: true, PostData: `{"query":"mutation AddToCart { add }"}`}, GraphQLQuery, "AddToCart", false},
+		{&Request{Method: "POST", HasPostData: true, PostData: `[{"query":"query A { a }"},{"query":"query B { b }"}]`}, GraphQLQuery, "B", true},
+		{&Request{Method: "POST", HasPostData: true}, "", "AddToCart", false}, // body over the limit of post data size
+	}
+	for i, c := range cases {
+		if got := MatchGraphQL(c.opType, c.name)(c.request); got != c.match {
+			t.Errorf("case %d: expected match %v, got %v", i, c.match, got)
+		}
+	}
+}
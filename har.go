@@ -0,0 +1,502 @@
+package control
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+const harVersion = "1.2"
+
+// HAR HTTP Archive 1.2 (http://www.softwareishard.com/blog/har-12-spec/)
+type HAR struct {
+	Log *HARLog `json:"log"`
+}
+
+type HARLog struct {
+	RunID   string      `json:"_runId,omitempty"` // BrowserContext.RunID of the recording
+	Version string      `json:"version"`
+	Creator *HARCreator `json:"creator"`
+	Pages   []*HARPage  `json:"pages"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HARPage struct {
+	StartedDateTime string          `json:"startedDateTime"`
+	ID              string          `json:"id"`
+	Title           string          `json:"title"`
+	PageTimings     *HARPageTimings `json:"pageTimings"`
+}
+
+type HARPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+type HAREntry struct {
+	PageRef         string       `json:"pageref,omitempty"`
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *HARRequest  `json:"request"`
+	Response        *HARResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *HARTimings  `json:"timings"`
+	ServerIPAddress string       `json:"serverIPAddress,omitempty"`
+	Connection      string       `json:"connection,omitempty"`
+	ResourceType    string       `json:"_resourceType,omitempty"`
+}
+
+type HARRequest struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARCookie    `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	QueryString []*HARNameValue `json:"queryString"`
+	PostData    *HARPostData    `json:"postData,omitempty"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int             `json:"status"`
+	StatusText  string          `json:"statusText"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARCookie    `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	Content     *HARContent     `json:"content"`
+	RedirectURL string          `json:"redirectURL"`
+	HeadersSize int             `json:"headersSize"`
+	BodySize    int             `json:"bodySize"`
+	Error       string          `json:"_error,omitempty"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings in milliseconds, -1 if the phase does not apply
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harEntry entry being recorded with monotonic timestamps (seconds) of the request
+type harEntry struct {
+	*HAREntry
+	started  float64
+	finished float64
+	timing   *network.ResourceTiming
+}
+
+type harPage struct {
+	*HARPage
+	started float64
+}
+
+// HARRecorder records network traffic of the session, see Session.RecordHAR
+type HARRecorder struct {
+	s        *Session
+	content  bool
+	mx       sync.Mutex
+	pages    []*harPage
+	entries  []*harEntry
+	inflight map[network.RequestId]*harEntry
+	pending  int        // bodies being fetched
+	fetched  *sync.Cond // signaled when pending is decreased, uses mx
+	cancel   func()
+}
+
+// RecordHAR start recording of network traffic of the session, bodies of responses are recorded if content is true
+func (s Session) RecordHAR(content bool) *HARRecorder {
+	var r = &HARRecorder{s: &s, content: content, inflight: map[network.RequestId]*harEntry{}}
+	r.fetched = sync.NewCond(&r.mx)
+	r.cancel = s.Subscribe("*", r.observe)
+	return r
+}
+
+// Stop stop recording, recorded traffic is still available for export
+func (r *HARRecorder) Stop() {
+	r.cancel()
+}
+
+func harTime(wallTime common.TimeSinceEpoch) string {
+	var sec = float64(wallTime)
+	return time.Unix(0, int64(sec*float64(time.Second))).UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// harHeaders headers sorted by name, repeated headers joined by "\n" are split
+func harHeaders(h *network.Headers) []*HARNameValue {
+	var list = []*HARNameValue{}
+	if h == nil {
+		return list
+	}
+	if m, ok := (*h).(map[string]interface{}); ok {
+		for name, value := range m {
+			v, _ := value.(string)
+			for _, line := range strings.Split(v, "\n") {
+				list = append(list, &HARNameValue{Name: name, Value: line})
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func harHeader(headers []*HARNameValue, name string) []string {
+	var values []string
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			values = append(values, h.Value)
+		}
+	}
+	return values
+}
+
+func harQueryString(rawURL string) []*HARNameValue {
+	var list = []*HARNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return list
+	}
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		var name, value = pair, ""
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			name, value = pair[:i], pair[i+1:]
+		}
+		name, _ = url.QueryUnescape(name)
+		value, _ = url.QueryUnescape(value)
+		list = append(list, &HARNameValue{Name: name, Value: value})
+	}
+	return list
+}
+
+func harRequestCookies(headers []*HARNameValue) []*HARCookie {
+	var (
+		list = []*HARCookie{}
+		req  = http.Request{Header: http.Header{"Cookie": harHeader(headers, "Cookie")}}
+	)
+	for _, c := range req.Cookies() {
+		list = append(list, &HARCookie{Name: c.Name, Value: c.Value})
+	}
+	return list
+}
+
+func harResponseCookies(headers []*HARNameValue) []*HARCookie {
+	var (
+		list = []*HARCookie{}
+		res  = http.Response{Header: http.Header{"Set-Cookie": harHeader(headers, "Set-Cookie")}}
+	)
+	for _, c := range res.Cookies() {
+		var cookie = &HARCookie{Name: c.Name, Value: c.Value, Path: c.Path, Domain: c.Domain, HTTPOnly: c.HttpOnly, Secure: c.Secure}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.UTC().Format(time.RFC3339)
+		}
+		list = append(list, cookie)
+	}
+	return list
+}
+
+func harHTTPVersion(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "":
+		return "HTTP/1.1"
+	case "h2":
+		return "HTTP/2.0"
+	case "h3":
+		return "HTTP/3.0"
+	default:
+		return strings.ToUpper(protocol)
+	}
+}
+
+// emptyHARResponse response of the request which was failed or is still in flight
+func emptyHARResponse(request *HARRequest) *HARResponse {
+	return &HARResponse{
+		Cookies:     []*HARCookie{},
+		Headers:     []*HARNameValue{},
+		Content:     &HARContent{MimeType: "x-unknown"},
+		HTTPVersion: request.HTTPVersion,
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
+
+func newHARRequest(v *network.RequestWillBeSent) *HARRequest {
+	var headers = harHeaders(v.Request.Headers)
+	var r = &HARRequest{
+		Method:      v.Request.Method,
+		URL:         v.Request.Url + v.Request.UrlFragment,
+		HTTPVersion: harHTTPVersion(""),
+		Cookies:     harRequestCookies(headers),
+		Headers:     headers,
+		QueryString: harQueryString(v.Request.Url),
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+	if v.Request.HasPostData {
+		var mime = harHeader(headers, "Content-Type")
+		r.PostData = &HARPostData{Text: v.Request.PostData}
+		if len(mime) > 0 {
+			r.PostData.MimeType = mime[0]
+		}
+		r.BodySize = len(v.Request.PostData)
+	}
+	return r
+}
+
+// setResponse fill response part of the entry and actual request headers sent by the browser
+func (e *harEntry) setResponse(v *network.Response) {
+	var headers = harHeaders(v.Headers)
+	e.Response = &HARResponse{
+		Status:      v.Status,
+		StatusText:  v.StatusText,
+		HTTPVersion: harHTTPVersion(v.Protocol),
+		Cookies:     harResponseCookies(headers),
+		Headers:     headers,
+		Content:     &HARContent{Size: -1, MimeType: v.MimeType},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if location := harHeader(headers, "Location"); len(location) > 0 {
+		e.Response.RedirectURL = location[0]
+	}
+	if v.HeadersText != "" {
+		e.Response.HeadersSize = len(v.HeadersText)
+	}
+	if v.RequestHeaders != nil {
+		e.Request.Headers = harHeaders(v.RequestHeaders)
+		e.Request.Cookies = harRequestCookies(e.Request.Headers)
+	}
+	if v.RequestHeadersText != "" {
+		e.Request.HeadersSize = len(v.RequestHeadersText)
+	}
+	e.Request.HTTPVersion = e.Response.HTTPVersion
+	e.ServerIPAddress = strings.Trim(v.RemoteIPAddress, "[]")
+	if v.ConnectionId != 0 {
+		e.Connection = strconv.FormatFloat(v.ConnectionId, 'f', -1, 64)
+	}
+	e.timing = v.Timing
+}
+
+// finish compute timings of the entry finished at monotonic timestamp
+func (e *harEntry) finish(timestamp float64) {
+	e.finished = timestamp
+	var t = requestPhases(e.started, e.finished, e.timing)
+	e.Timings = &t
+	e.Time = t.total()
+}
+
+func (r *HARRecorder) observe(e transport.Event) {
+	switch e.Method {
+	case "Network.requestWillBeSent":
+		var v = network.RequestWillBeSent{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Request == nil {
+			return
+		}
+		r.mx.Lock()
+		defer r.mx.Unlock()
+		if prev, ok := r.inflight[v.RequestId]; ok && v.RedirectResponse != nil {
+			prev.setResponse(v.RedirectResponse)
+			prev.Response.RedirectURL = v.Request.Url
+			prev.finish(float64(v.Timestamp))
+		}
+		if v.Type == resourceTypeDocument && v.FrameId == common.FrameId(r.s.tid) && v.RedirectResponse == nil {
+			r.pages = append(r.pages, &harPage{
+				HARPage: &HARPage{
+					StartedDateTime: harTime(v.WallTime),
+					ID:              "page_" + strconv.Itoa(len(r.pages)+1),
+					Title:           v.Request.Url,
+					PageTimings:     &HARPageTimings{OnContentLoad: -1, OnLoad: -1},
+				},
+				started: float64(v.Timestamp),
+			})
+		}
+		var entry = &harEntry{
+			HAREntry: &HAREntry{
+				StartedDateTime: harTime(v.WallTime),
+				Request:         newHARRequest(&v),
+				ResourceType:    strings.ToLower(string(v.Type)),
+			},
+			started: float64(v.Timestamp),
+		}
+		if len(r.pages) > 0 {
+			entry.PageRef = r.pages[len(r.pages)-1].ID
+		}
+		r.inflight[v.RequestId] = entry
+		r.entries = append(r.entries, entry)
+
+	case "Network.responseReceived":
+		var v = network.ResponseReceived{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Response == nil {
+			return
+		}
+		r.mx.Lock()
+		if entry, ok := r.inflight[v.RequestId]; ok {
+			entry.setResponse(v.Response)
+		}
+		r.mx.Unlock()
+
+	case "Network.loadingFinished":
+		var v = network.LoadingFinished{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		r.mx.Lock()
+		entry, ok := r.inflight[v.RequestId]
+		delete(r.inflight, v.RequestId)
+		if ok && entry.Response != nil {
+			entry.finish(float64(v.Timestamp))
+			entry.Response.BodySize = int(v.EncodedDataLength)
+			if entry.Response.HeadersSize > 0 {
+				entry.Response.BodySize -= entry.Response.HeadersSize
+			}
+			if r.content {
+				r.pending++
+				go r.fetchBody(v.RequestId, entry)
+			}
+		}
+		r.mx.Unlock()
+
+	case "Network.loadingFailed":
+		var v = network.LoadingFailed{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		r.mx.Lock()
+		if entry, ok := r.inflight[v.RequestId]; ok {
+			delete(r.inflight, v.RequestId)
+			if entry.Response == nil {
+				entry.Response = emptyHARResponse(entry.Request)
+			}
+			entry.Response.Error = v.ErrorText
+			entry.finish(float64(v.Timestamp))
+		}
+		r.mx.Unlock()
+
+	case "Page.domContentEventFired", "Page.loadEventFired":
+		var v = page.LoadEventFired{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		r.mx.Lock()
+		if n := len(r.pages); n > 0 {
+			var p = r.pages[n-1]
+			var ms = (float64(v.Timestamp) - p.started) * 1000
+			if e.Method == "Page.loadEventFired" {
+				p.PageTimings.OnLoad = ms
+			} else {
+				p.PageTimings.OnContentLoad = ms
+			}
+		}
+		r.mx.Unlock()
+	}
+}
+
+func (r *HARRecorder) fetchBody(id network.RequestId, entry *harEntry) {
+	val, err := network.GetResponseBody(r.s, network.GetResponseBodyArgs{RequestId: id})
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.pending--
+	r.fetched.Broadcast()
+	if err != nil {
+		return
+	}
+	var content = entry.Response.Content
+	content.Text = val.Body
+	content.Size = len(val.Body)
+	if val.Base64Encoded {
+		content.Encoding = "base64"
+		if body, err := base64.StdEncoding.DecodeString(val.Body); err == nil {
+			content.Size = len(body)
+		}
+	}
+}
+
+// HAR returns archive of the traffic recorded so far, requests in flight are included without timings.
+// It waits for bodies of finished requests being fetched
+func (r *HARRecorder) HAR() *HAR {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for r.pending > 0 {
+		r.fetched.Wait()
+	}
+	var log = &HARLog{
+		RunID:   r.s.browser.RunID,
+		Version: harVersion,
+		Creator: &HARCreator{Name: "github.com/ecwid/control", Version: harVersion},
+		Pages:   make([]*HARPage, len(r.pages)),
+		Entries: make([]*HAREntry, 0, len(r.entries)),
+	}
+	for i, p := range r.pages {
+		log.Pages[i] = p.HARPage
+	}
+	for _, e := range r.entries {
+		var entry = *e.HAREntry
+		if entry.Timings == nil {
+			entry.Timings = &HARTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1}
+		}
+		if entry.Response == nil {
+			entry.Response = emptyHARResponse(entry.Request)
+		}
+		log.Entries = append(log.Entries, &entry)
+	}
+	return &HAR{Log: log}
+}
+
+// ExportHAR write HAR archive of the recorded traffic as JSON, recorder should be stopped to export consistent archive
+func (r *HARRecorder) ExportHAR(w io.Writer) error {
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.HAR())
+}
@@ -0,0 +1,91 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/ecwid/control/protocol/network"
+)
+
+func TestHAREntryTimings(t *testing.T) {
+	var cases = []struct {
+		name     string
+		started  float64
+		finished float64
+		timing   *network.ResourceTiming
+		expect   HARTimings
+		time     float64
+	}{
+		{
+			name:    "new TLS connection",
+			started: 10, finished: 11,
+			timing: &network.ResourceTiming{
+				RequestTime: 10.5,
+				DnsStart:    1, DnsEnd: 3,
+				ConnectStart: 3, ConnectEnd: 8,
+				SslStart: 5, SslEnd: 8,
+				SendStart: 8, SendEnd: 9,
+				ReceiveHeadersEnd: 20,
+			},
+			expect: HARTimings{Blocked: 501, DNS: 2, Connect: 5, SSL: 3, Send: 1, Wait: 11, Receive: 480},
+			time:   1000,
+		},
+		{
+			name:    "reused connection",
+			started: 10, finished: 10.25,
+			timing: &network.ResourceTiming{
+				RequestTime: 10,
+				DnsStart:    -1, DnsEnd: -1,
+				ConnectStart: -1, ConnectEnd: -1,
+				SslStart: -1, SslEnd: -1,
+				SendStart: 0.5, SendEnd: 1,
+				ReceiveHeadersEnd: 4,
+			},
+			expect: HARTimings{Blocked: 0.5, DNS: -1, Connect: -1, SSL: -1, Send: 0.5, Wait: 3, Receive: 246},
+			time:   250,
+		},
+		{
+			name:    "no timing",
+			started: 10, finished: 10.125,
+			expect: HARTimings{Blocked: 0, DNS: -1, Connect: -1, SSL: -1, Receive: 125},
+			time:   125,
+		},
+		{
+			name:    "failed before headers",
+			started: 10, finished: 10.001,
+			timing: &network.ResourceTiming{
+				RequestTime: 10,
+				DnsStart:    -1, DnsEnd: -1,
+				ConnectStart: -1, ConnectEnd: -1,
+				SslStart: -1, SslEnd: -1,
+				SendStart: 0.25, SendEnd: 0.5,
+				ReceiveHeadersEnd: -1,
+			},
+			expect: HARTimings{Blocked: 0.25, DNS: -1, Connect: -1, SSL: -1, Send: 0.25, Wait: 0, Receive: 2},
+			time:   2.5,
+		},
+	}
+	for _, c := range cases {
+		var e = &harEntry{HAREntry: &HAREntry{}, started: c.started, timing: c.timing}
+		e.finish(c.finished)
+		if !equalTimings(*e.Timings, c.expect) || !nearly(e.Time, c.time) {
+			t.Errorf("%s: expected %+v (%v ms), got %+v (%v ms)", c.name, c.expect, c.time, *e.Timings, e.Time)
+		}
+		// request metrics report the same breakdown
+		var m = &requestMetrics{RequestMetrics: &RequestMetrics{}, issued: c.started, timing: c.timing}
+		m.finish(c.finished)
+		if total := m.Timing.Total.Seconds() * 1000; !nearly(total, c.time) {
+			t.Errorf("%s: expected total of request metrics %v ms, got %v ms", c.name, c.time, total)
+		}
+	}
+}
+
+// nearly equal milliseconds up to a microsecond
+func nearly(a, b float64) bool {
+	const epsilon = 1e-3
+	return a-b < epsilon && b-a < epsilon
+}
+
+func equalTimings(a, b HARTimings) bool {
+	return nearly(a.Blocked, b.Blocked) && nearly(a.DNS, b.DNS) && nearly(a.Connect, b.Connect) && nearly(a.SSL, b.SSL) &&
+		nearly(a.Send, b.Send) && nearly(a.Wait, b.Wait) && nearly(a.Receive, b.Receive)
+}
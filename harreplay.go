@@ -0,0 +1,135 @@
+package control
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+// HARNotFound behaviour of HAR replay for requests missing in the archive
+type HARNotFound int
+
+const (
+	HARNotFoundContinue HARNotFound = iota // send request to the live network
+	HARNotFoundAbort                       // fail request with BlockedByClient
+)
+
+// HARReplayOptions options of Session.ReplayHAR
+type HARReplayOptions struct {
+	URLPattern    string // wildcard pattern of replayed requests, all requests if empty
+	NotFound      HARNotFound
+	MatchPostData bool // requests with body are matched by post data as well
+}
+
+// LoadHAR read HAR archive from the file
+func LoadHAR(path string) (*HAR, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var har = &HAR{}
+	if err = json.Unmarshal(data, har); err != nil {
+		return nil, err
+	}
+	return har, nil
+}
+
+func harReplayKey(method, url, postData string) string {
+	return method + " " + url + "\n" + postData
+}
+
+// harReplay entries of the archive by request, repeated requests are served by entries in recorded order
+// and the last entry is served when they are exhausted
+type harReplay struct {
+	mx      sync.Mutex
+	entries map[string][]*HAREntry
+}
+
+func newHARReplay(har *HAR, matchPostData bool) *harReplay {
+	var r = &harReplay{entries: map[string][]*HAREntry{}}
+	for _, e := range har.Log.Entries {
+		if e.Request == nil || e.Response == nil {
+			continue
+		}
+		var postData string
+		if matchPostData && e.Request.PostData != nil {
+			postData = e.Request.PostData.Text
+		}
+		var key = harReplayKey(e.Request.Method, e.Request.URL, postData)
+		r.entries[key] = append(r.entries[key], e)
+	}
+	return r
+}
+
+func (r *harReplay) next(key string) *HAREntry {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	var list = r.entries[key]
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+	r.entries[key] = list[1:]
+	return list[0]
+}
+
+func harResponseBody(c *HARContent) ([]byte, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+// fulfill respond to the route with recorded response, failed requests are failed again
+func (r *harReplay) fulfill(route *Route, e *HAREntry) error {
+	if e.Response.Error != "" || e.Response.Status == 0 {
+		return route.Fail("Failed")
+	}
+	body, err := harResponseBody(e.Response.Content)
+	if err != nil {
+		return err
+	}
+	var headers = make([]*fetch.HeaderEntry, 0, len(e.Response.Headers))
+	for _, h := range e.Response.Headers {
+		headers = append(headers, &fetch.HeaderEntry{Name: h.Name, Value: h.Value})
+	}
+	return route.FulfillWith(fetch.FulfillRequestArgs{
+		ResponseCode:    e.Response.Status,
+		ResponseHeaders: decodedBodyHeaders(headers),
+		Body:            body,
+		ResponsePhrase:  e.Response.StatusText,
+	})
+}
+
+// ReplayHAR serve requests matching opts.URLPattern from the archive (see LoadHAR, HARRecorder.ExportHAR).
+// Requests are matched by method and url (and post data if opts.MatchPostData), requests missing
+// in the archive are sent to the network or failed according to opts.NotFound
+func (s Session) ReplayHAR(har *HAR, opts HARReplayOptions) (cancel func(), err error) {
+	var replay = newHARReplay(har, opts.MatchPostData)
+	return s.Intercept(fetch.RequestPattern{UrlPattern: opts.URLPattern}, func(route *Route) {
+		var postData string
+		if opts.MatchPostData && route.Request.HasPostData {
+			postData = route.Request.PostData
+			if postData == "" {
+				postData, _ = s.Network.GetRequestPostData(network.RequestId(route.NetworkId))
+			}
+		}
+		var url = route.Request.Url + route.Request.UrlFragment
+		if e := replay.next(harReplayKey(route.Request.Method, url, postData)); e != nil {
+			_ = replay.fulfill(route, e)
+			return
+		}
+		if opts.NotFound == HARNotFoundAbort {
+			_ = route.Fail("BlockedByClient")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package control
+
+import "testing"
+
+func TestHARReplayMatching(t *testing.T) {
+	var entry = func(method, url, postData string, status int) *HAREntry {
+		var e = &HAREntry{Request: &HARRequest{Method: method, URL: url}, Response: &HARResponse{Status: status}}
+		if postData != "" {
+			e.Request.PostData = &HARPostData{Text: postData}
+		}
+		return e
+	}
+	var har = &HAR{Log: &HARLog{}}
+	har.Log.Entries = []*HAREntry{
+		entry("GET", "https://example.com/", "", 200),
+		entry("GET", "https://example.com/poll", "", 201),
+		entry("GET", "https://example.com/poll", "", 202),
+		entry("POST", "https://example.com/search", `{"q":"a"}`, 210),
+		entry("POST", "https://example.com/search", `{"q":"b"}`, 211),
+		{Request: &HARRequest{Method: "GET", URL: "https://example.com/pending"}},
+	}
+	var cases = []struct {
+		name          string
+		matchPostData bool
+		requests      []string // keys of harReplayKey
+		expect        []int    // status of served entries, 0 if not found
+	}{
+		{
+			name:     "method and url",
+			requests: []string{harReplayKey("GET", "https://example.com/", ""), harReplayKey("POST", "https://example.com/", "")},
+			expect:   []int{200, 0},
+		},
+		{
+			name: "repeated requests in recorded order, the last one is served when exhausted",
+			requests: []string{
+				harReplayKey("GET", "https://example.com/poll", ""),
+				harReplayKey("GET", "https://example.com/poll", ""),
+				harReplayKey("GET", "https://example.com/poll", ""),
+			},
+			expect: []int{201, 202, 202},
+		},
+		{
+			name:     "post data is ignored",
+			requests: []string{harReplayKey("POST", "https://example.com/search", ""), harReplayKey("POST", "https://example.com/search", "")},
+			expect:   []int{210, 211},
+		},
+		{
+			name:          "post data is matched",
+			matchPostData: true,
+			requests: []string{
+				harReplayKey("POST", "https://example.com/search", `{"q":"b"}`),
+				harReplayKey("POST", "https://example.com/search", `{"q":"c"}`),
+				harReplayKey("POST", "https://example.com/search", ""),
+				harReplayKey("GET", "https://example.com/", ""),
+			},
+			expect: []int{211, 0, 0, 200},
+		},
+		{
+			name:     "entries without response are skipped",
+			requests: []string{harReplayKey("GET", "https://example.com/pending", "")},
+			expect:   []int{0},
+		},
+	}
+	for _, c := range cases {
+		var replay = newHARReplay(har, c.matchPostData)
+		for i, key := range c.requests {
+			var status = 0
+			if e := replay.next(key); e != nil {
+				status = e.Response.Status
+			}
+			if status != c.expect[i] {
+				t.Errorf("%s: request %d expected %d, got %d", c.name, i, c.expect[i], status)
+			}
+		}
+	}
+}
+
+func TestHARResponseBody(t *testing.T) {
+	var cases = []struct {
+		content *HARContent
+		expect  string
+		fails   bool
+	}{
+		{nil, "", false},
+		{&HARContent{Text: "plain"}, "plain", false},
+		{&HARContent{Text: "YmluYXJ5", Encoding: "base64"}, "binary", false},
+		{&HARContent{Text: "%%%", Encoding: "base64"}, "", true},
+	}
+	for _, c := range cases {
+		body, err := harResponseBody(c.content)
+		if (err != nil) != c.fails || string(body) != c.expect {
+			t.Errorf("%+v: expected `%s` (fails %v), got `%s` (%v)", c.content, c.expect, c.fails, body, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package control
+
+import "sync"
+
+// extraHeaders extra HTTP headers of the session sent with every request
+type extraHeaders struct {
+	mx     sync.Mutex
+	values map[string]string
+}
+
+func (h *extraHeaders) get() map[string]string {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	var values = make(map[string]string, len(h.values))
+	for name, value := range h.values {
+		values[name] = value
+	}
+	return values
+}
+
+func (h *extraHeaders) replace(values map[string]string) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.values = make(map[string]string, len(values))
+	for name, value := range values {
+		h.values[name] = value
+	}
+}
+
+func (h *extraHeaders) merge(values map[string]string) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	for name, value := range values {
+		if value == "" {
+			delete(h.values, name)
+		} else {
+			h.values[name] = value
+		}
+	}
+}
+
+// SetExtraHeaders add headers (e.g. Authorization, X-Request-ID) to every request of the session,
+// headers set before are kept and header with empty value is removed
+func (n Network) SetExtraHeaders(headers map[string]string) error {
+	n.s.extraHeaders.merge(headers)
+	return n.applyExtraHeaders()
+}
+
+// ExtraHeaders returns extra headers set by SetExtraHeaders or SetExtraHTTPHeaders
+func (n Network) ExtraHeaders() map[string]string {
+	return n.s.extraHeaders.get()
+}
+
+// ClearExtraHeaders remove all extra headers of the session, run id and environment headers are kept
+func (n Network) ClearExtraHeaders() error {
+	return n.SetExtraHTTPHeaders(nil)
+}
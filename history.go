@@ -0,0 +1,125 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	ActionClick = "click"
+	ActionHover = "hover"
+	ActionInput = "input"
+	ActionType  = "type"
+)
+
+// ElementHistory aggregated history of actions with the element path across a run
+type ElementHistory struct {
+	Path      string
+	Actions   map[string]int // attempts by action
+	Failures  int
+	Recovered int            // failures followed by successful action on the same path
+	Errors    map[string]int // failures by error kind
+	failed    bool
+}
+
+type actionHistory struct {
+	mx       sync.Mutex
+	elements map[string]*ElementHistory
+}
+
+func newActionHistory() *actionHistory {
+	return &actionHistory{elements: map[string]*ElementHistory{}}
+}
+
+func errorKind(err error) string {
+	var target ClickTargetOverlappedError
+	if errors.As(err, &target) {
+		return "ClickTargetOverlappedError"
+	}
+	if kind := fmt.Sprintf("%T", err); kind != "*errors.errorString" {
+		return strings.TrimPrefix(kind, "control.")
+	}
+	return err.Error()
+}
+
+func (h *actionHistory) record(path, action string, err error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	v, ok := h.elements[path]
+	if !ok {
+		v = &ElementHistory{Path: path, Actions: map[string]int{}, Errors: map[string]int{}}
+		h.elements[path] = v
+	}
+	v.Actions[action]++
+	if err != nil {
+		v.Failures++
+		v.Errors[errorKind(err)]++
+		v.failed = true
+		return
+	}
+	if v.failed {
+		v.Recovered++
+		v.failed = false
+	}
+}
+
+// path selector of the element or its description if it was not found by selector
+func (e Element) path() string {
+	if e.selector != "" {
+		return e.selector
+	}
+	return e.Description()
+}
+
+func (e Element) record(action string, err error) {
+	e.frame.session.browser.history.record(e.path(), action, err)
+}
+
+// FlakeReport history of elements with failed actions, the most unstable first
+func (b BrowserContext) FlakeReport() []ElementHistory {
+	b.history.mx.Lock()
+	defer b.history.mx.Unlock()
+	var report []ElementHistory
+	for _, v := range b.history.elements {
+		if v.Failures == 0 {
+			continue
+		}
+		var e = *v
+		e.Actions, e.Errors = map[string]int{}, map[string]int{}
+		for key, value := range v.Actions {
+			e.Actions[key] = value
+		}
+		for key, value := range v.Errors {
+			e.Errors[key] = value
+		}
+		report = append(report, e)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Failures != report[j].Failures {
+			return report[i].Failures > report[j].Failures
+		}
+		return report[i].Path < report[j].Path
+	})
+	return report
+}
+
+// ActionHistory history of actions with the element path
+func (b BrowserContext) ActionHistory(path string) (ElementHistory, bool) {
+	b.history.mx.Lock()
+	defer b.history.mx.Unlock()
+	v, ok := b.history.elements[path]
+	if !ok {
+		return ElementHistory{}, false
+	}
+	return *v, true
+}
+
+// ResetActionHistory forget history of all elements
+func (b BrowserContext) ResetActionHistory() {
+	b.history.mx.Lock()
+	defer b.history.mx.Unlock()
+	b.history.elements = map[string]*ElementHistory{}
+}
@@ -0,0 +1,49 @@
+package control
+
+import (
+	"time"
+)
+
+const (
+	hoverIntentSteps = 10
+	hoverIntentDelay = time.Millisecond * 20
+)
+
+// HoverAndWaitFor move the mouse over the element in small steps (from its left edge to the middle) to trigger
+// hover-intent handlers, then keep it inside the element until tooltip matched by selector is visible
+func (e Element) HoverAndWaitFor(tooltipSelector string, timeout time.Duration) (*Element, error) {
+	e.frame.session.slowDown()
+	unlock := e.frame.lockActions()
+	if err := e.ScrollIntoView(); err != nil {
+		unlock()
+		return nil, err
+	}
+	quad, err := e.GetContentQuad(true)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	var (
+		x, y   = quad.Middle()
+		startX = (quad[0].X+quad[3].X)/2 + 1
+		startY = (quad[0].Y + quad[3].Y) / 2
+		input  = e.frame.Session().Input
+	)
+	for i := 0; i <= hoverIntentSteps; i++ {
+		k := float64(i) / hoverIntentSteps
+		if err = input.MouseMove(MouseNone, startX+(x-startX)*k, startY+(y-startY)*k); err != nil {
+			unlock()
+			return nil, err
+		}
+		e.frame.session.Clock().Sleep(hoverIntentDelay)
+	}
+	unlock()
+	// tooltip is looked up in the frame of the element
+	var visible = func(Session) (func() (bool, error), func()) {
+		return elementVisible(e.frame, tooltipSelector), func() {}
+	}
+	if err = e.frame.Session().Wait(visible, timeout); err != nil {
+		return nil, err
+	}
+	return e.frame.QuerySelector(tooltipSelector)
+}
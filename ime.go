@@ -0,0 +1,49 @@
+package control
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/ecwid/control/protocol/input"
+)
+
+// SetComposition set current candidate text of IME composition (compositionstart/compositionupdate events)
+// with caret at the end of the text, empty text cancels composition
+func (i Input) SetComposition(text string) error {
+	var end = utf8.RuneCountInString(text)
+	return input.ImeSetComposition(i.s, input.ImeSetCompositionArgs{Text: text, SelectionStart: end, SelectionEnd: end})
+}
+
+// CommitComposition commit composition with the final text (compositionend event)
+func (i Input) CommitComposition(text string) error {
+	return i.insertText(text)
+}
+
+// CancelComposition cancel current composition
+func (i Input) CancelComposition() error {
+	return i.SetComposition("")
+}
+
+// Compose type text with IME: candidates are shown one after another with delay between them (e.g. "n", "ni", "nih",
+// "niha", "nihao", "你好") then the final text is committed, as CJK input methods and emoji pickers do
+func (i Input) Compose(candidates []string, final string, delay time.Duration) error {
+	i.s.slowDown()
+	i.mx.Lock()
+	defer i.mx.Unlock()
+	for _, text := range candidates {
+		if err := i.SetComposition(text); err != nil {
+			_ = i.CancelComposition()
+			return err
+		}
+		i.s.Clock().Sleep(delay)
+	}
+	return i.CommitComposition(final)
+}
+
+// Compose focus element and type text with IME, see Input.Compose
+func (e Element) Compose(candidates []string, final string, delay time.Duration) error {
+	if err := e.Focus(); err != nil {
+		return err
+	}
+	return e.frame.Session().Input.Compose(candidates, final, delay)
+}
@@ -3,6 +3,7 @@ package control
 import (
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ecwid/control/protocol/input"
 )
@@ -133,20 +134,27 @@ var keyDefinitions = map[rune]KeyDefinition{
 }
 
 type Input struct {
-	mx *sync.Mutex
-	s  *Session
+	mx      *sync.Mutex
+	s       *Session
+	pointer *pointer
 }
 
-func (i Input) Click(button input.MouseButton, x, y float64, delay time.Duration) (err error) {
+func (i Input) Click(button input.MouseButton, x, y float64, delay time.Duration) error {
+	i.s.slowDown()
 	i.mx.Lock()
 	defer i.mx.Unlock()
-	if err = i.MouseMove(MouseNone, x, y); err != nil {
+	return i.click(button, x, y, delay)
+}
+
+// click caller holds input lock
+func (i Input) click(button input.MouseButton, x, y float64, delay time.Duration) (err error) {
+	if err = i.moveTo(x, y); err != nil {
 		return err
 	}
 	if err = i.MousePress(button, x, y); err != nil {
 		return err
 	}
-	time.Sleep(delay)
+	i.s.Clock().Sleep(delay)
 	if err = i.MouseRelease(button, x, y); err != nil {
 		return err
 	}
@@ -154,6 +162,7 @@ func (i Input) Click(button input.MouseButton, x, y float64, delay time.Duration
 }
 
 func (i Input) MouseMove(button input.MouseButton, x, y float64) error {
+	i.pointer.moved(x, y)
 	return input.DispatchMouseEvent(i.s, input.DispatchMouseEventArgs{
 		X:          x,
 		Y:          y,
@@ -164,6 +173,7 @@ func (i Input) MouseMove(button input.MouseButton, x, y float64) error {
 }
 
 func (i Input) MousePress(button input.MouseButton, x, y float64) error {
+	i.pointer.moved(x, y)
 	return input.DispatchMouseEvent(i.s, input.DispatchMouseEventArgs{
 		X:          x,
 		Y:          y,
@@ -174,6 +184,7 @@ func (i Input) MousePress(button input.MouseButton, x, y float64) error {
 }
 
 func (i Input) MouseRelease(button input.MouseButton, x, y float64) error {
+	i.pointer.moved(x, y)
 	return input.DispatchMouseEvent(i.s, input.DispatchMouseEventArgs{
 		X:          x,
 		Y:          y,
@@ -183,6 +194,41 @@ func (i Input) MouseRelease(button input.MouseButton, x, y float64) error {
 	})
 }
 
+// Wheel dispatch mouse wheel event at the point of the viewport, positive deltaY scrolls down
+// and positive deltaX scrolls right (in CSS pixels)
+func (i Input) Wheel(x, y, deltaX, deltaY float64) error {
+	i.s.slowDown()
+	return i.wheel(x, y, deltaX, deltaY)
+}
+
+func (i Input) wheel(x, y, deltaX, deltaY float64) error {
+	return input.DispatchMouseEvent(i.s, input.DispatchMouseEventArgs{
+		X:      x,
+		Y:      y,
+		Type:   "mouseWheel",
+		DeltaX: deltaX,
+		DeltaY: deltaY,
+	})
+}
+
+// WheelSteps scroll by deltaX, deltaY split into steps wheel events with interval between them,
+// as precise touchpad scrolling does
+func (i Input) WheelSteps(x, y, deltaX, deltaY float64, steps int, interval time.Duration) error {
+	if steps < 1 {
+		steps = 1
+	}
+	i.s.slowDown()
+	for n := 0; n < steps; n++ {
+		if n > 0 {
+			i.s.Clock().Sleep(interval)
+		}
+		if err := i.wheel(x, y, deltaX/float64(steps), deltaY/float64(steps)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Keyboard events
 const (
 	dispatchKeyEventKeyDown = "keyDown"
@@ -190,6 +236,11 @@ const (
 )
 
 func (i Input) InsertText(text string) error {
+	i.s.slowDown()
+	return i.insertText(text)
+}
+
+func (i Input) insertText(text string) error {
 	return input.InsertText(i.s, input.InsertTextArgs{Text: text})
 }
 
@@ -197,8 +248,14 @@ func (i Input) PressKey(c rune) error {
 	return i.Press(KeyDefinition{KeyCode: int(c), Text: string(c)})
 }
 
+// Press press and release key, text of non-printable keys (e.g. Keys["ArrowDown"]) is not inserted
 func (i Input) Press(key KeyDefinition) error {
-	if key.Text == "" {
+	i.s.slowDown()
+	return i.press(key)
+}
+
+func (i Input) press(key KeyDefinition) error {
+	if key.Text == "" && utf8.RuneCountInString(key.Key) == 1 {
 		key.Text = key.Key
 	}
 	err := input.DispatchKeyEvent(i.s, input.DispatchKeyEventArgs{
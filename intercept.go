@@ -0,0 +1,296 @@
+package control
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+const (
+	StageRequest  fetch.RequestStage = "Request"
+	StageResponse fetch.RequestStage = "Response"
+)
+
+// Route request paused by the interception, it must be continued, fulfilled or failed exactly once.
+// Route that is not resolved by any handler is continued
+type Route struct {
+	fetch.RequestPaused
+	session  *Session
+	resolved *int32
+}
+
+// RouteHandler handles paused request, route is passed to the next matched handler if it's not resolved
+type RouteHandler func(route *Route)
+
+// IsResponseStage true if request is paused after the response is received
+func (r Route) IsResponseStage() bool {
+	return r.ResponseStatusCode != 0 || r.ResponseErrorReason != ""
+}
+
+// IsResolved true if route was already continued, fulfilled or failed
+func (r Route) IsResolved() bool {
+	return atomic.LoadInt32(r.resolved) == 1
+}
+
+func (r Route) resolve() bool {
+	return atomic.CompareAndSwapInt32(r.resolved, 0, 1)
+}
+
+// Continue continue request unmodified
+func (r Route) Continue() error {
+	return r.ContinueWith(fetch.ContinueRequestArgs{})
+}
+
+// ContinueWith continue request with overrides of url, method, post data or headers
+func (r Route) ContinueWith(args fetch.ContinueRequestArgs) error {
+	if !r.resolve() {
+		return nil
+	}
+	args.RequestId = r.RequestId
+	return fetch.ContinueRequest(r.session, args)
+}
+
+// Fulfill respond to the request with given status code, headers and body
+func (r Route) Fulfill(code int, headers map[string]string, body []byte) error {
+	if !r.resolve() {
+		return nil
+	}
+	var entries = make([]*fetch.HeaderEntry, 0, len(headers))
+	for name, value := range headers {
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: value})
+	}
+	return fetch.FulfillRequest(r.session, fetch.FulfillRequestArgs{
+		RequestId:       r.RequestId,
+		ResponseCode:    code,
+		ResponseHeaders: entries,
+		Body:            body,
+	})
+}
+
+// FulfillWith respond to the request with raw fulfill arguments (e.g. repeated headers)
+func (r Route) FulfillWith(args fetch.FulfillRequestArgs) error {
+	if !r.resolve() {
+		return nil
+	}
+	args.RequestId = r.RequestId
+	return fetch.FulfillRequest(r.session, args)
+}
+
+// ResponseBody returns body of the response, available at the response stage only
+func (r Route) ResponseBody() ([]byte, error) {
+	val, err := fetch.GetResponseBody(r.session, fetch.GetResponseBodyArgs{RequestId: r.RequestId})
+	if err != nil {
+		return nil, err
+	}
+	if val.Base64Encoded {
+		return base64.StdEncoding.DecodeString(val.Body)
+	}
+	return []byte(val.Body), nil
+}
+
+// Fail fail request with given network error reason (e.g. "Failed", "Aborted", "BlockedByClient")
+func (r Route) Fail(reason network.ErrorReason) error {
+	if !r.resolve() {
+		return nil
+	}
+	return fetch.FailRequest(r.session, fetch.FailRequestArgs{
+		RequestId:   r.RequestId,
+		ErrorReason: reason,
+	})
+}
+
+type route struct {
+	id      uint64
+	pattern fetch.RequestPattern
+	url     *regexp.Regexp
+	handler RouteHandler
+}
+
+func (r route) match(v fetch.RequestPaused) bool {
+	if r.pattern.ResourceType != "" && r.pattern.ResourceType != v.ResourceType {
+		return false
+	}
+	var responseStage = v.ResponseStatusCode != 0 || v.ResponseErrorReason != ""
+	if (r.pattern.RequestStage == StageResponse) != responseStage {
+		return false
+	}
+	return r.url.MatchString(v.Request.Url)
+}
+
+type routes struct {
+	mx          sync.Mutex
+	enableMx    sync.Mutex // serializes Fetch.enable/disable round-trips, they're done without mx
+	seq         uint64
+	routes      []*route
+	credentials *fetch.AuthChallengeResponse            // credentials of auth challenges, nil if challenges aren't handled
+	scoped      map[string]*fetch.AuthChallengeResponse // credentials of auth challenges of the origin
+	challenged  map[fetch.RequestId]bool                // requests already answered with credentials
+	intercepted map[network.RequestId]fetch.RequestId   // interception of the request, while auth challenges are handled
+	errorHooks  map[uint64]func(error)                  // hooks of Fetch.enable failures nobody can return error to
+}
+
+// compileURLPattern wildcard pattern of Fetch domain where '*' is zero or more, '?' is exactly one character
+// and '\' is escape
+func compileURLPattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		pattern = "*"
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	var runes = []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// paused the last registered matching handler is called first, handlers are called outside of event loop
+func (r *routes) paused(s *Session, v fetch.RequestPaused) {
+	var paused = &Route{RequestPaused: v, session: s, resolved: new(int32)}
+	r.mx.Lock()
+	if r.credentials != nil || len(r.scoped) > 0 {
+		r.intercept(network.RequestId(v.NetworkId), v.RequestId)
+	}
+	var handlers []RouteHandler
+	for i := len(r.routes) - 1; i >= 0; i-- {
+		if r.routes[i].match(v) {
+			handlers = append(handlers, r.routes[i].handler)
+		}
+	}
+	r.mx.Unlock()
+	go func() {
+		for _, h := range handlers {
+			h(paused)
+			if paused.IsResolved() {
+				return
+			}
+		}
+		_ = paused.Continue()
+	}()
+}
+
+// enable Fetch domain with patterns of all registered routes or disable it if there are no routes
+// and auth challenges aren't handled. Handling of challenges requires all requests to be paused.
+// Patterns are collected under the lock of routes, but the round-trip is done without it,
+// so paused and authRequired don't block the event loop of the session
+func (r *routes) enable(s Session) error {
+	r.enableMx.Lock()
+	defer r.enableMx.Unlock()
+	r.mx.Lock()
+	var disable, args = r.enableArgs()
+	r.mx.Unlock()
+	if disable {
+		return fetch.Disable(s)
+	}
+	return fetch.Enable(s, args)
+}
+
+// enableArgs arguments of Fetch.enable for registered routes and auth challenges, disable is true if
+// Fetch domain isn't required. Lock of routes is required
+func (r *routes) enableArgs() (disable bool, args fetch.EnableArgs) {
+	var handleAuth = r.credentials != nil || len(r.scoped) > 0
+	if len(r.routes) == 0 && !handleAuth {
+		return true, args
+	}
+	var patterns = make([]*fetch.RequestPattern, len(r.routes))
+	for i, v := range r.routes {
+		var p = v.pattern
+		patterns[i] = &p
+	}
+	if r.credentials != nil {
+		patterns = append(patterns, &fetch.RequestPattern{UrlPattern: "*"})
+	} else {
+		for origin := range r.scoped {
+			patterns = append(patterns, &fetch.RequestPattern{UrlPattern: origin + "/*"})
+		}
+	}
+	return false, fetch.EnableArgs{Patterns: patterns, HandleAuthRequests: handleAuth}
+}
+
+// remove forget the route, false if it's already removed
+func (r *routes) remove(id uint64) bool {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for i, v := range r.routes {
+		if v.id == id {
+			r.routes = append(r.routes[:i], r.routes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// failed report error of Fetch.enable that can't be returned to the caller (e.g. of cancel of Intercept)
+func (r *routes) failed(err error) {
+	r.mx.Lock()
+	var hooks = make([]func(error), 0, len(r.errorHooks))
+	for _, h := range r.errorHooks {
+		hooks = append(hooks, h)
+	}
+	r.mx.Unlock()
+	for _, h := range hooks {
+		h(err)
+	}
+}
+
+// Intercept pause requests matching pattern and pass them to the handler.
+// Requests are routed to the last registered handler first, unresolved routes are continued
+func (s Session) Intercept(pattern fetch.RequestPattern, handler RouteHandler) (cancel func(), err error) {
+	if pattern.UrlPattern == "" {
+		pattern.UrlPattern = "*"
+	}
+	s.routes.mx.Lock()
+	s.routes.seq++
+	var v = &route{id: s.routes.seq, pattern: pattern, url: compileURLPattern(pattern.UrlPattern), handler: handler}
+	s.routes.routes = append(s.routes.routes, v)
+	s.routes.mx.Unlock()
+	if err = s.routes.enable(s); err != nil {
+		s.routes.remove(v.id)
+		return nil, err
+	}
+	return func() {
+		if !s.routes.remove(v.id) {
+			return
+		}
+		if err := s.routes.enable(s); err != nil {
+			s.routes.failed(err)
+		}
+	}, nil
+}
+
+// OnRouteError register hook called when interception can't be updated in the background,
+// e.g. Fetch.enable of cancel returned by Intercept fails and requests of the canceled route are still paused
+// (they are continued unmodified)
+func (s Session) OnRouteError(hook func(err error)) (cancel func()) {
+	s.routes.mx.Lock()
+	defer s.routes.mx.Unlock()
+	s.routes.seq++
+	var id = s.routes.seq
+	if s.routes.errorHooks == nil {
+		s.routes.errorHooks = map[uint64]func(error){}
+	}
+	s.routes.errorHooks[id] = hook
+	return func() {
+		s.routes.mx.Lock()
+		defer s.routes.mx.Unlock()
+		delete(s.routes.errorHooks, id)
+	}
+}
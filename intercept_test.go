@@ -0,0 +1,34 @@
+package control
+
+import "testing"
+
+func TestCompileURLPattern(t *testing.T) {
+	var cases = []struct {
+		pattern string
+		url     string
+		match   bool
+	}{
+		{"", "https://example.com/", true},
+		{"*", "https://example.com/any?q=1", true},
+		{"https://example.com/*", "https://example.com/api/pets", true},
+		{"https://example.com/*", "https://example.org/api/pets", false},
+		{"*/api/pets", "https://example.com/api/pets", true},
+		{"*/api/pets", "https://example.com/api/pets/1", false},
+		{"*/pets/?", "https://example.com/pets/1", true},
+		{"*/pets/?", "https://example.com/pets/12", false},
+		{"*.js?v=1", "https://example.com/app.js?v=1", true},
+		{"*.js?v=1", "https://example.com/appxjs?v=1", false},
+		{`*/a\*b`, "https://example.com/a*b", true},
+		{`*/a\*b`, "https://example.com/axxb", false},
+		{`*/a\?b`, "https://example.com/a?b", true},
+		{`*/a\?b`, "https://example.com/axb", false},
+		{"*/(group)[0]", "https://example.com/(group)[0]", true},
+		{"*/категория/?", "https://example.com/категория/1", true},
+		{"*/к?т", "https://example.com/кот", true},
+	}
+	for _, c := range cases {
+		if got := compileURLPattern(c.pattern).MatchString(c.url); got != c.match {
+			t.Errorf("pattern `%s` url `%s`: expected match %v, got %v", c.pattern, c.url, c.match, got)
+		}
+	}
+}
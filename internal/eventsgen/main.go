@@ -0,0 +1,124 @@
+// Command eventsgen generates typed event subscriptions of events.go from event types of the protocol packages.
+// Run it from the module root: go run ./internal/eventsgen
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type domain struct {
+	pkg      string // package of the protocol domain
+	name     string // domain name of events
+	receiver string // receiver of subscriptions
+	session  string // expression of the session by receiver
+}
+
+var domains = []domain{
+	{pkg: "page", name: "Page", receiver: "s Session", session: "s"},
+	{pkg: "runtime", name: "Runtime", receiver: "s Session", session: "s"},
+	{pkg: "target", name: "Target", receiver: "s Session", session: "s"},
+	{pkg: "network", name: "Network", receiver: "n Network", session: "n.s"},
+}
+
+// skip events subscribed by hand-written high-level methods
+var skip = map[string]bool{
+	"Page.backForwardCacheNotUsed":       true, // Session.OnBackForwardCacheNotUsed
+	"Network.requestWillBeSent":          true, // Network.OnRequest
+	"Network.responseReceived":           true, // Network.OnResponse
+	"Network.eventSourceMessageReceived": true, // Network.OnEventSourceMessage
+}
+
+const header = `// Code generated by go run ./internal/eventsgen; DO NOT EDIT.
+
+package control
+
+import (
+	"encoding/json"
+
+%s
+	"github.com/ecwid/control/transport"
+)
+`
+
+const subscription = `
+// On%[1]s subscribe to %[2]s
+func (%[3]s) On%[1]s(handler func(%[4]s.%[1]s)) (cancel func()) {
+	return %[5]s.Subscribe("%[2]s", func(e transport.Event) {
+		var v = %[4]s.%[1]s{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			%[5]s.eventDecodeFailed(e, err)
+			return
+		}
+		handler(v)
+	})
+}
+`
+
+// events returns names of struct types declared in events.go of the protocol package, params of events
+// without parameters are declared as interface{} and aren't subscribed
+func events(pkg string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join("protocol", pkg, "events.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if t := spec.(*ast.TypeSpec); isStruct(t.Type) {
+				names = append(names, t.Name.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+func isStruct(expr ast.Expr) bool {
+	_, ok := expr.(*ast.StructType)
+	return ok
+}
+
+func main() {
+	var (
+		imports []string
+		body    bytes.Buffer
+	)
+	for _, d := range domains {
+		names, err := events(d.pkg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		imports = append(imports, fmt.Sprintf("\t\"github.com/ecwid/control/protocol/%s\"", d.pkg))
+		for _, name := range names {
+			var method = d.name + "." + strings.ToLower(name[:1]) + name[1:]
+			if skip[method] {
+				continue
+			}
+			fmt.Fprintf(&body, subscription, name, method, d.receiver, d.pkg, d.session)
+		}
+	}
+	sort.Strings(imports)
+	var src bytes.Buffer
+	fmt.Fprintf(&src, header, strings.Join(imports, "\n"))
+	src.Write(body.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("events.go", formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,140 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/runtime"
+)
+
+// JSHandle reference to arbitrary remote object (object, array, function or primitive) of the frame
+type JSHandle struct {
+	object *runtime.RemoteObject
+	frame  *Frame
+}
+
+// EvaluateHandle evaluate expression in main frame and return handle of the result
+func (s Session) EvaluateHandle(expression string) (*JSHandle, error) {
+	return s.Page().EvaluateHandle(expression)
+}
+
+// EvaluateHandle evaluate expression (promise is awaited) and return handle of the result
+func (f Frame) EvaluateHandle(expression string) (*JSHandle, error) {
+	val, err := f.evaluate(expression, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &JSHandle{object: val, frame: &f}, nil
+}
+
+// Handle returns JSHandle of the element
+func (e Element) Handle() *JSHandle {
+	return &JSHandle{object: e.runtime, frame: e.frame}
+}
+
+// RemoteObject ...
+func (h JSHandle) RemoteObject() *runtime.RemoteObject {
+	return h.object
+}
+
+// Type object type (object, function, string, number, ...)
+func (h JSHandle) Type() string {
+	return h.object.Type
+}
+
+// Subtype object subtype hint (array, node, null, promise, ...)
+func (h JSHandle) Subtype() string {
+	return h.object.Subtype
+}
+
+func (h JSHandle) Description() string {
+	return h.object.Description
+}
+
+// CallArgument reference to the object to pass it as argument of function call
+func (h JSHandle) CallArgument() *runtime.CallArgument {
+	if h.object.ObjectId != "" {
+		return &runtime.CallArgument{ObjectId: h.object.ObjectId}
+	}
+	if h.object.UnserializableValue != "" {
+		return &runtime.CallArgument{UnserializableValue: h.object.UnserializableValue}
+	}
+	return &runtime.CallArgument{Value: h.object.Value}
+}
+
+func (h JSHandle) callFunction(function string, returnByValue bool, args ...interface{}) (*runtime.RemoteObject, error) {
+	val, err := runtime.CallFunctionOn(h.frame, runtime.CallFunctionOnArgs{
+		FunctionDeclaration: function,
+		ObjectId:            h.object.ObjectId,
+		AwaitPromise:        true,
+		ReturnByValue:       returnByValue,
+		Arguments:           newCallArguments(args...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	return val.Result, nil
+}
+
+// Call call function with the object as `this`, args may contain other handles, returns handle of the result
+func (h JSHandle) Call(function string, args ...interface{}) (*JSHandle, error) {
+	if h.object.ObjectId == "" {
+		return nil, RemoteObjectCastError{object: primitiveRemoteObject(*h.object), cast: "object"}
+	}
+	val, err := h.callFunction(function, false, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &JSHandle{object: val, frame: h.frame}, nil
+}
+
+// GetProperty returns handle of the object property
+func (h JSHandle) GetProperty(name string) (*JSHandle, error) {
+	return h.Call(`function(n){return this[n]}`, name)
+}
+
+// GetProperties returns handles of own enumerable properties of the object
+func (h JSHandle) GetProperties() (map[string]*JSHandle, error) {
+	if h.object.ObjectId == "" {
+		return nil, RemoteObjectCastError{object: primitiveRemoteObject(*h.object), cast: "object"}
+	}
+	descriptors, err := h.frame.getProperties(h.object.ObjectId, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var properties = map[string]*JSHandle{}
+	for _, d := range descriptors {
+		if d.Enumerable && d.Value != nil {
+			properties[d.Name] = &JSHandle{object: d.Value, frame: h.frame}
+		}
+	}
+	return properties, nil
+}
+
+// JSONValue decode JSON-serializable value of the object into out
+func (h JSHandle) JSONValue(out interface{}) error {
+	if h.object.ObjectId == "" {
+		return primitiveRemoteObject(*h.object).unmarshal(out)
+	}
+	val, err := h.callFunction(`function(){return this}`, true)
+	if err != nil {
+		return err
+	}
+	return primitiveRemoteObject(*val).unmarshal(out)
+}
+
+// AsElement returns element if the object is DOM node
+func (h JSHandle) AsElement() (*Element, error) {
+	if h.object.Subtype != "node" {
+		return nil, RemoteObjectCastError{object: primitiveRemoteObject(*h.object), cast: "node"}
+	}
+	return h.frame.constructElement(h.object)
+}
+
+// Dispose release the remote object, handle can't be used after
+func (h JSHandle) Dispose() error {
+	if h.object.ObjectId == "" {
+		return nil
+	}
+	return runtime.ReleaseObject(h.frame, runtime.ReleaseObjectArgs{ObjectId: h.object.ObjectId})
+}
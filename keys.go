@@ -0,0 +1,194 @@
+package control
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Keys named keys of US keyboard layout by key or code name, e.g. "Enter", "ArrowDown", "F5", "Numpad1", "KeyA"
+var Keys = map[string]KeyDefinition{
+	"Shift":          KeyShift,
+	"Control":        KeyControl,
+	"Alt":            KeyAlt,
+	"Meta":           KeyMeta,
+	"ShiftRight":     {KeyCode: 16, Key: "Shift", Code: "ShiftRight", Location: 2},
+	"ControlRight":   {KeyCode: 17, Key: "Control", Code: "ControlRight", Location: 2},
+	"AltRight":       {KeyCode: 18, Key: "Alt", Code: "AltRight", Location: 2},
+	"MetaRight":      {KeyCode: 92, Key: "Meta", Code: "MetaRight", Location: 2},
+	"Enter":          {KeyCode: 13, Key: "Enter", Code: "Enter", Text: "\r"},
+	"Tab":            {KeyCode: 9, Key: "Tab", Code: "Tab"},
+	"Backspace":      {KeyCode: 8, Key: "Backspace", Code: "Backspace"},
+	"Escape":         {KeyCode: 27, Key: "Escape", Code: "Escape"},
+	"Space":          {KeyCode: 32, Key: " ", Code: "Space", Text: " "},
+	"Delete":         {KeyCode: 46, Key: "Delete", Code: "Delete"},
+	"Insert":         {KeyCode: 45, Key: "Insert", Code: "Insert"},
+	"Home":           {KeyCode: 36, Key: "Home", Code: "Home"},
+	"End":            {KeyCode: 35, Key: "End", Code: "End"},
+	"PageUp":         {KeyCode: 33, Key: "PageUp", Code: "PageUp"},
+	"PageDown":       {KeyCode: 34, Key: "PageDown", Code: "PageDown"},
+	"ArrowLeft":      {KeyCode: 37, Key: "ArrowLeft", Code: "ArrowLeft"},
+	"ArrowUp":        {KeyCode: 38, Key: "ArrowUp", Code: "ArrowUp"},
+	"ArrowRight":     {KeyCode: 39, Key: "ArrowRight", Code: "ArrowRight"},
+	"ArrowDown":      {KeyCode: 40, Key: "ArrowDown", Code: "ArrowDown"},
+	"CapsLock":       {KeyCode: 20, Key: "CapsLock", Code: "CapsLock"},
+	"NumLock":        {KeyCode: 144, Key: "NumLock", Code: "NumLock"},
+	"ScrollLock":     {KeyCode: 145, Key: "ScrollLock", Code: "ScrollLock"},
+	"Pause":          {KeyCode: 19, Key: "Pause", Code: "Pause"},
+	"PrintScreen":    {KeyCode: 44, Key: "PrintScreen", Code: "PrintScreen"},
+	"ContextMenu":    {KeyCode: 93, Key: "ContextMenu", Code: "ContextMenu"},
+	"F1":             {KeyCode: 112, Key: "F1", Code: "F1"},
+	"F2":             {KeyCode: 113, Key: "F2", Code: "F2"},
+	"F3":             {KeyCode: 114, Key: "F3", Code: "F3"},
+	"F4":             {KeyCode: 115, Key: "F4", Code: "F4"},
+	"F5":             {KeyCode: 116, Key: "F5", Code: "F5"},
+	"F6":             {KeyCode: 117, Key: "F6", Code: "F6"},
+	"F7":             {KeyCode: 118, Key: "F7", Code: "F7"},
+	"F8":             {KeyCode: 119, Key: "F8", Code: "F8"},
+	"F9":             {KeyCode: 120, Key: "F9", Code: "F9"},
+	"F10":            {KeyCode: 121, Key: "F10", Code: "F10"},
+	"F11":            {KeyCode: 122, Key: "F11", Code: "F11"},
+	"F12":            {KeyCode: 123, Key: "F12", Code: "F12"},
+	"Numpad0":        {KeyCode: 96, Key: "0", Code: "Numpad0", Location: 3},
+	"Numpad1":        {KeyCode: 97, Key: "1", Code: "Numpad1", Location: 3},
+	"Numpad2":        {KeyCode: 98, Key: "2", Code: "Numpad2", Location: 3},
+	"Numpad3":        {KeyCode: 99, Key: "3", Code: "Numpad3", Location: 3},
+	"Numpad4":        {KeyCode: 100, Key: "4", Code: "Numpad4", Location: 3},
+	"Numpad5":        {KeyCode: 101, Key: "5", Code: "Numpad5", Location: 3},
+	"Numpad6":        {KeyCode: 102, Key: "6", Code: "Numpad6", Location: 3},
+	"Numpad7":        {KeyCode: 103, Key: "7", Code: "Numpad7", Location: 3},
+	"Numpad8":        {KeyCode: 104, Key: "8", Code: "Numpad8", Location: 3},
+	"Numpad9":        {KeyCode: 105, Key: "9", Code: "Numpad9", Location: 3},
+	"NumpadEnter":    {KeyCode: 13, Key: "Enter", Code: "NumpadEnter", Text: "\r", Location: 3},
+	"NumpadAdd":      {KeyCode: 107, Key: "+", Code: "NumpadAdd", Location: 3},
+	"NumpadSubtract": {KeyCode: 109, Key: "-", Code: "NumpadSubtract", Location: 3},
+	"NumpadMultiply": {KeyCode: 106, Key: "*", Code: "NumpadMultiply", Location: 3},
+	"NumpadDivide":   {KeyCode: 111, Key: "/", Code: "NumpadDivide", Location: 3},
+	"NumpadDecimal":  {KeyCode: 110, Key: ".", Code: "NumpadDecimal", Location: 3},
+	"Digit0":         {KeyCode: 48, Key: "0", Code: "Digit0"},
+	"Digit1":         {KeyCode: 49, Key: "1", Code: "Digit1"},
+	"Digit2":         {KeyCode: 50, Key: "2", Code: "Digit2"},
+	"Digit3":         {KeyCode: 51, Key: "3", Code: "Digit3"},
+	"Digit4":         {KeyCode: 52, Key: "4", Code: "Digit4"},
+	"Digit5":         {KeyCode: 53, Key: "5", Code: "Digit5"},
+	"Digit6":         {KeyCode: 54, Key: "6", Code: "Digit6"},
+	"Digit7":         {KeyCode: 55, Key: "7", Code: "Digit7"},
+	"Digit8":         {KeyCode: 56, Key: "8", Code: "Digit8"},
+	"Digit9":         {KeyCode: 57, Key: "9", Code: "Digit9"},
+	"KeyA":           {KeyCode: 65, Key: "a", Code: "KeyA"},
+	"KeyB":           {KeyCode: 66, Key: "b", Code: "KeyB"},
+	"KeyC":           {KeyCode: 67, Key: "c", Code: "KeyC"},
+	"KeyD":           {KeyCode: 68, Key: "d", Code: "KeyD"},
+	"KeyE":           {KeyCode: 69, Key: "e", Code: "KeyE"},
+	"KeyF":           {KeyCode: 70, Key: "f", Code: "KeyF"},
+	"KeyG":           {KeyCode: 71, Key: "g", Code: "KeyG"},
+	"KeyH":           {KeyCode: 72, Key: "h", Code: "KeyH"},
+	"KeyI":           {KeyCode: 73, Key: "i", Code: "KeyI"},
+	"KeyJ":           {KeyCode: 74, Key: "j", Code: "KeyJ"},
+	"KeyK":           {KeyCode: 75, Key: "k", Code: "KeyK"},
+	"KeyL":           {KeyCode: 76, Key: "l", Code: "KeyL"},
+	"KeyM":           {KeyCode: 77, Key: "m", Code: "KeyM"},
+	"KeyN":           {KeyCode: 78, Key: "n", Code: "KeyN"},
+	"KeyO":           {KeyCode: 79, Key: "o", Code: "KeyO"},
+	"KeyP":           {KeyCode: 80, Key: "p", Code: "KeyP"},
+	"KeyQ":           {KeyCode: 81, Key: "q", Code: "KeyQ"},
+	"KeyR":           {KeyCode: 82, Key: "r", Code: "KeyR"},
+	"KeyS":           {KeyCode: 83, Key: "s", Code: "KeyS"},
+	"KeyT":           {KeyCode: 84, Key: "t", Code: "KeyT"},
+	"KeyU":           {KeyCode: 85, Key: "u", Code: "KeyU"},
+	"KeyV":           {KeyCode: 86, Key: "v", Code: "KeyV"},
+	"KeyW":           {KeyCode: 87, Key: "w", Code: "KeyW"},
+	"KeyX":           {KeyCode: 88, Key: "x", Code: "KeyX"},
+	"KeyY":           {KeyCode: 89, Key: "y", Code: "KeyY"},
+	"KeyZ":           {KeyCode: 90, Key: "z", Code: "KeyZ"},
+	"Minus":          {KeyCode: 189, Key: "-", Code: "Minus"},
+	"Equal":          {KeyCode: 187, Key: "=", Code: "Equal"},
+	"BracketLeft":    {KeyCode: 219, Key: "[", Code: "BracketLeft"},
+	"BracketRight":   {KeyCode: 221, Key: "]", Code: "BracketRight"},
+	"Backslash":      {KeyCode: 220, Key: "\\", Code: "Backslash"},
+	"Semicolon":      {KeyCode: 186, Key: ";", Code: "Semicolon"},
+	"Quote":          {KeyCode: 222, Key: "'", Code: "Quote"},
+	"Backquote":      {KeyCode: 192, Key: "`", Code: "Backquote"},
+	"Comma":          {KeyCode: 188, Key: ",", Code: "Comma"},
+	"Period":         {KeyCode: 190, Key: ".", Code: "Period"},
+	"Slash":          {KeyCode: 191, Key: "/", Code: "Slash"},
+}
+
+// usShifted pairs of unshifted and shifted characters of US keyboard layout, letters are shifted to upper case
+const usShifted = "`~1!2@3#4$5%6^7&8*9(0)-_=+[{]}\\|;:'\",<.>/?"
+
+// shiftedKey key pressed while Shift is held, e.g. "a" gives "A" and "1" gives "!"
+func shiftedKey(key KeyDefinition) KeyDefinition {
+	if key.ShiftKey != "" {
+		key.Key, key.Text = key.ShiftKey, key.ShiftText
+		if key.ShiftKeyCode != 0 {
+			key.KeyCode = key.ShiftKeyCode
+		}
+		return key
+	}
+	r, size := utf8.DecodeRuneInString(key.Key)
+	if size == 0 || size != len(key.Key) {
+		return key
+	}
+	var shifted = unicode.ToUpper(r)
+	if n := strings.IndexRune(usShifted, r); n >= 0 && n%2 == 0 {
+		shifted, _ = utf8.DecodeRuneInString(usShifted[n+1:])
+	}
+	if shifted == r {
+		return key
+	}
+	key.Key = string(shifted)
+	if key.Text != "" {
+		key.Text = key.Key
+	}
+	return key
+}
+
+// lookupKey named key or printable character
+func lookupKey(name string) (KeyDefinition, error) {
+	if key, ok := Keys[name]; ok {
+		return key, nil
+	}
+	if r, size := utf8.DecodeRuneInString(name); size == len(name) && size > 0 {
+		if key, ok := keyDefinitions[r]; ok {
+			return key, nil
+		}
+	}
+	return KeyDefinition{}, UnknownKeyError{Key: name}
+}
+
+// parseKeyCombo keys of combination joined by "+" in order of pressing, e.g. "Control+Shift+ArrowLeft" or "Control++"
+func parseKeyCombo(combo string) ([]KeyDefinition, error) {
+	var parts = strings.Split(combo, "+")
+	if strings.HasSuffix(combo, "++") || combo == "+" { // "+" key itself
+		parts = append(parts[:len(parts)-2], "+")
+	}
+	var list = make([]KeyDefinition, len(parts))
+	for n, name := range parts {
+		key, err := lookupKey(name)
+		if err != nil {
+			return nil, err
+		}
+		list[n] = key
+	}
+	return list, nil
+}
+
+// Press press keys one after another, key is a name of Keys, printable character or combination
+// of them joined by "+", e.g. Press("Control+A", "Delete", "Shift+ArrowDown", "Enter")
+func (s Session) Press(keys ...string) error {
+	var chain = s.Input.Actions()
+	for _, combo := range keys {
+		list, err := parseKeyCombo(combo)
+		if err != nil {
+			return err
+		}
+		for _, key := range list {
+			chain.KeyDown(key)
+		}
+		for n := len(list) - 1; n >= 0; n-- {
+			chain.KeyUp(list[n])
+		}
+	}
+	return chain.Perform()
+}
@@ -0,0 +1,90 @@
+package control
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ecwid/control/protocol/input"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+func TestShiftedKey(t *testing.T) {
+	var cases = []struct {
+		key    KeyDefinition
+		expect KeyDefinition
+	}{
+		{Keys["KeyA"], KeyDefinition{KeyCode: 65, Key: "A", Code: "KeyA"}},
+		{Keys["Digit1"], KeyDefinition{KeyCode: 49, Key: "!", Code: "Digit1"}},
+		{Keys["Slash"], KeyDefinition{KeyCode: 191, Key: "?", Code: "Slash"}},
+		{Keys["Backquote"], KeyDefinition{KeyCode: 192, Key: "~", Code: "Backquote"}},
+		{Keys["Enter"], Keys["Enter"]},
+		{Keys["Space"], Keys["Space"]},
+		{Keys["ArrowDown"], Keys["ArrowDown"]},
+		{KeyDefinition{Key: "x", Text: "x"}, KeyDefinition{Key: "X", Text: "X"}},
+		{KeyDefinition{Key: "ä", Text: "ä"}, KeyDefinition{Key: "Ä", Text: "Ä"}},
+		{
+			KeyDefinition{KeyCode: 50, Key: "2", Text: "2", ShiftKey: "\"", ShiftText: "\"", ShiftKeyCode: 222},
+			KeyDefinition{KeyCode: 222, Key: "\"", Text: "\"", ShiftKey: "\"", ShiftText: "\"", ShiftKeyCode: 222},
+		},
+	}
+	for _, c := range cases {
+		if got := shiftedKey(c.key); got != c.expect {
+			t.Errorf("%+v: expected %+v, got %+v", c.key, c.expect, got)
+		}
+	}
+}
+
+func TestParseKeyCombo(t *testing.T) {
+	var cases = []struct {
+		combo  string
+		expect []KeyDefinition
+		err    error
+	}{
+		{"Enter", []KeyDefinition{Keys["Enter"]}, nil},
+		{"a", []KeyDefinition{keyDefinitions['a']}, nil},
+		{"Control+A", []KeyDefinition{KeyControl, keyDefinitions['A']}, nil},
+		{"Control+Shift+ArrowLeft", []KeyDefinition{KeyControl, KeyShift, Keys["ArrowLeft"]}, nil},
+		{"+", []KeyDefinition{keyDefinitions['+']}, nil},
+		{"Control++", []KeyDefinition{KeyControl, keyDefinitions['+']}, nil},
+		{"Control+Unknown", nil, UnknownKeyError{Key: "Unknown"}},
+		{"a+", nil, UnknownKeyError{Key: ""}},
+	}
+	for _, c := range cases {
+		list, err := parseKeyCombo(c.combo)
+		if err != c.err || !reflect.DeepEqual(list, c.expect) {
+			t.Errorf("`%s`: expected %+v (%v), got %+v (%v)", c.combo, c.expect, c.err, list, err)
+		}
+	}
+}
+
+func TestPress(t *testing.T) {
+	var script = make([]cdptest.Message, 0, 8)
+	for id := 1; id <= 4; id++ {
+		script = append(script, call(id, "Input.dispatchKeyEvent", ""), reply(id, `{}`))
+	}
+	s, srv, log := testSession(t, script...)
+	if err := s.Press("Shift+a"); err != nil {
+		t.Fatal(err)
+	}
+	played(t, srv)
+	var expect = []input.DispatchKeyEventArgs{
+		{Type: "rawKeyDown", Modifiers: ModifierShift, Key: "Shift", Code: "ShiftLeft", WindowsVirtualKeyCode: 16, Location: 1},
+		{Type: "keyDown", Modifiers: ModifierShift, Key: "A", Code: "KeyA", WindowsVirtualKeyCode: 65, Text: "A"},
+		{Type: "keyUp", Modifiers: ModifierShift, Key: "A", Code: "KeyA", WindowsVirtualKeyCode: 65},
+		{Type: "keyUp", Key: "Shift", Code: "ShiftLeft", WindowsVirtualKeyCode: 16, Location: 1},
+	}
+	var sent = log.sent(t, "Input.dispatchKeyEvent")
+	if len(sent) != len(expect) {
+		t.Fatalf("expected %d key events, got %d", len(expect), len(sent))
+	}
+	for i, params := range sent {
+		var got = input.DispatchKeyEventArgs{}
+		if err := json.Unmarshal(params, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, expect[i]) {
+			t.Errorf("event %d: expected %+v, got %+v", i, expect[i], got)
+		}
+	}
+}
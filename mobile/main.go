@@ -1,6 +1,9 @@
 package mobile
 
 import (
+	"strings"
+
+	"github.com/ecwid/control/protocol/common"
 	"github.com/ecwid/control/protocol/emulation"
 )
 
@@ -16,7 +19,36 @@ const (
 // Device device description
 type Device struct {
 	Metrics   emulation.SetDeviceMetricsOverrideArgs
-	UserAgent string
+	UserAgent string // empty means user agent of the browser
+	// Metadata user agent client hints consistent with UserAgent, nil means hints derived by the browser
+	Metadata *common.UserAgentMetadata
+	Touch    bool
+}
+
+func chromeBrands(major, full string) ([]*common.UserAgentBrandVersion, []*common.UserAgentBrandVersion) {
+	return []*common.UserAgentBrandVersion{
+		{Brand: "Chromium", Version: major},
+		{Brand: "Not A(Brand", Version: "24"},
+		{Brand: "Google Chrome", Version: major},
+	}, []*common.UserAgentBrandVersion{
+		{Brand: "Chromium", Version: full},
+		{Brand: "Not A(Brand", Version: "24.0.0.0"},
+		{Brand: "Google Chrome", Version: full},
+	}
+}
+
+// androidMetadata client hints of Chrome for Android of the full version (e.g. "110.0.0.0") matching user agent
+func androidMetadata(chromeVersion, platformVersion, model string) *common.UserAgentMetadata {
+	brands, fullVersionList := chromeBrands(strings.SplitN(chromeVersion, ".", 2)[0], chromeVersion)
+	return &common.UserAgentMetadata{
+		Brands:          brands,
+		FullVersionList: fullVersionList,
+		FullVersion:     chromeVersion,
+		Platform:        "Android",
+		PlatformVersion: platformVersion,
+		Model:           model,
+		Mobile:          true,
+	}
 }
 
 var (
@@ -24,9 +56,11 @@ var (
 	ScreenOrientationPortrait  = &emulation.ScreenOrientation{Type: PortraitPrimary, Angle: 0}
 )
 
+// Safari user agents of iOS presets, Safari doesn't send client hints so these presets have no Metadata
 var (
-	iphoneUA = "Mozilla/5.0 (iPhone; CPU iPhone OS 11_0 like Mac OS X) AppleWebKit/604.1.38 (KHTML, like Gecko) Version/11.0 Mobile/15A372 Safari/604.1"
-	ipadUA   = "Mozilla/5.0 (iPad; CPU OS 11_0 like Mac OS X) AppleWebKit/604.1.34 (KHTML, like Gecko) Version/11.0 Mobile/15A5341f Safari/604.1"
+	iphoneUA   = "Mozilla/5.0 (iPhone; CPU iPhone OS 11_0 like Mac OS X) AppleWebKit/604.1.38 (KHTML, like Gecko) Version/11.0 Mobile/15A372 Safari/604.1"
+	iphone14UA = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1"
+	ipadUA     = "Mozilla/5.0 (iPad; CPU OS 11_0 like Mac OS X) AppleWebKit/604.1.34 (KHTML, like Gecko) Version/11.0 Mobile/15A5341f Safari/604.1"
 )
 
 // Predefined devices
@@ -40,6 +74,8 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: "Mozilla/5.0 (Linux; Android 5.0; SM-G900P Build/LRX21T) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/75.0.3765.0 Mobile Safari/537.36",
+		Metadata:  androidMetadata("75.0.3765.0", "5.0", "SM-G900P"),
+		Touch:     true,
 	}
 
 	Pixel2 = &Device{
@@ -51,6 +87,8 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: "Mozilla/5.0 (Linux; Android 8.0; Pixel 2 Build/OPD3.170816.012) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/75.0.3765.0 Mobile Safari/537.36",
+		Metadata:  androidMetadata("75.0.3765.0", "8.0", "Pixel 2"),
+		Touch:     true,
 	}
 
 	Pixel2XL = &Device{
@@ -62,6 +100,8 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: "Mozilla/5.0 (Linux; Android 8.0.0; Pixel 2 XL Build/OPD1.170816.004) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/75.0.3765.0 Mobile Safari/537.36",
+		Metadata:  androidMetadata("75.0.3765.0", "8.0.0", "Pixel 2 XL"),
+		Touch:     true,
 	}
 
 	IPad = &Device{
@@ -73,6 +113,7 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: ipadUA,
+		Touch:     true,
 	}
 
 	IPadMini = IPad
@@ -86,6 +127,7 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: ipadUA,
+		Touch:     true,
 	}
 
 	IPhone6 = &Device{
@@ -97,6 +139,7 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: iphoneUA,
+		Touch:     true,
 	}
 	IPhone7 = IPhone6
 	IPhone8 = IPhone6
@@ -110,6 +153,7 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: iphoneUA,
+		Touch:     true,
 	}
 	IPhone7Plus = IPhone6Plus
 	IPhone8Plus = IPhone6Plus
@@ -123,5 +167,95 @@ var (
 			ScreenOrientation: ScreenOrientationPortrait,
 		},
 		UserAgent: iphoneUA,
+		Touch:     true,
+	}
+
+	IPhone12 = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             390,
+			Height:            844,
+			DeviceScaleFactor: 3,
+			Mobile:            true,
+			ScreenOrientation: ScreenOrientationPortrait,
+		},
+		UserAgent: iphone14UA,
+		Touch:     true,
+	}
+	IPhone13 = IPhone12
+	IPhone14 = IPhone12
+
+	Pixel5 = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             393,
+			Height:            851,
+			DeviceScaleFactor: 2.75,
+			Mobile:            true,
+			ScreenOrientation: ScreenOrientationPortrait,
+		},
+		UserAgent: "Mozilla/5.0 (Linux; Android 11; Pixel 5) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36",
+		Metadata:  androidMetadata("110.0.0.0", "11", "Pixel 5"),
+		Touch:     true,
+	}
+
+	Pixel7 = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             412,
+			Height:            915,
+			DeviceScaleFactor: 2.625,
+			Mobile:            true,
+			ScreenOrientation: ScreenOrientationPortrait,
+		},
+		UserAgent: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36",
+		Metadata:  androidMetadata("110.0.0.0", "13", "Pixel 7"),
+		Touch:     true,
+	}
+
+	DesktopHD = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             1366,
+			Height:            768,
+			DeviceScaleFactor: 1,
+		},
+	}
+
+	DesktopFullHD = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             1920,
+			Height:            1080,
+			DeviceScaleFactor: 1,
+		},
+	}
+
+	MacBook = &Device{
+		Metrics: emulation.SetDeviceMetricsOverrideArgs{
+			Width:             1440,
+			Height:            900,
+			DeviceScaleFactor: 2,
+		},
 	}
 )
+
+// Devices catalog of predefined devices by name
+var Devices = map[string]*Device{
+	"Galaxy S5":       GalaxyS5,
+	"Pixel 2":         Pixel2,
+	"Pixel 2 XL":      Pixel2XL,
+	"Pixel 5":         Pixel5,
+	"Pixel 7":         Pixel7,
+	"iPad":            IPad,
+	"iPad Mini":       IPadMini,
+	"iPad Pro":        IPadPro,
+	"iPhone 6":        IPhone6,
+	"iPhone 7":        IPhone7,
+	"iPhone 8":        IPhone8,
+	"iPhone 6 Plus":   IPhone6Plus,
+	"iPhone 7 Plus":   IPhone7Plus,
+	"iPhone 8 Plus":   IPhone8Plus,
+	"iPhone X":        IPhoneX,
+	"iPhone 12":       IPhone12,
+	"iPhone 13":       IPhone13,
+	"iPhone 14":       IPhone14,
+	"Desktop HD":      DesktopHD,
+	"Desktop Full HD": DesktopFullHD,
+	"MacBook":         MacBook,
+}
@@ -0,0 +1,107 @@
+package control
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MousePath movement of the mouse to the target of Click and Hover along interpolated curve instead of teleporting
+type MousePath struct {
+	Steps     int           // intermediate moves
+	Duration  time.Duration // duration of the movement
+	Curvature float64       // max deviation of the curve from the straight line relative to the distance, e.g. 0.2
+	Seed      int64         // seed of random curvature, paths are reproducible with the same seed
+}
+
+// pointer last known mouse position of the session
+type pointer struct {
+	mx    sync.Mutex
+	x, y  float64
+	known bool
+	path  *MousePath
+	rand  *rand.Rand
+}
+
+func (p *pointer) moved(x, y float64) {
+	p.mx.Lock()
+	p.x, p.y, p.known = x, y, true
+	p.mx.Unlock()
+}
+
+// SetMousePath enable movement along interpolated path for Click, Hover and MoveTo, nil disables it
+func (i Input) SetMousePath(path *MousePath) {
+	i.pointer.mx.Lock()
+	defer i.pointer.mx.Unlock()
+	if path == nil {
+		i.pointer.path = nil
+		return
+	}
+	var v = *path
+	if v.Steps < 1 {
+		v.Steps = 1
+	}
+	i.pointer.path = &v
+	i.pointer.rand = rand.New(rand.NewSource(v.Seed))
+}
+
+// easeInOut slow start and finish of the movement as human hand moves
+func easeInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// waypoints points of quadratic bezier curve from (x0, y0) to (x1, y1) with control point randomly shifted
+// perpendicular to the straight line
+func (p *pointer) waypoints(x0, y0, x1, y1 float64) []Point {
+	var (
+		dx, dy = x1 - x0, y1 - y0
+		dist   = math.Hypot(dx, dy)
+		shift  = (p.rand.Float64()*2 - 1) * p.path.Curvature * dist
+		cx, cy = (x0 + x1) / 2, (y0 + y1) / 2
+	)
+	if dist > 0 {
+		cx, cy = cx-dy/dist*shift, cy+dx/dist*shift
+	}
+	var points = make([]Point, p.path.Steps)
+	for n := range points {
+		var t = easeInOut(float64(n+1) / float64(p.path.Steps))
+		points[n] = Point{
+			X: (1-t)*(1-t)*x0 + 2*(1-t)*t*cx + t*t*x1,
+			Y: (1-t)*(1-t)*y0 + 2*(1-t)*t*cy + t*t*y1,
+		}
+	}
+	points[len(points)-1] = Point{X: x1, Y: y1}
+	return points
+}
+
+// MoveTo move the mouse to the point, along the path of SetMousePath if it's enabled and the last position is known
+func (i Input) MoveTo(x, y float64) error {
+	i.s.slowDown()
+	return i.moveTo(x, y)
+}
+
+func (i Input) moveTo(x, y float64) error {
+	i.pointer.mx.Lock()
+	if i.pointer.path == nil || !i.pointer.known {
+		i.pointer.mx.Unlock()
+		return i.MouseMove(MouseNone, x, y)
+	}
+	var (
+		points   = i.pointer.waypoints(i.pointer.x, i.pointer.y, x, y)
+		interval time.Duration
+	)
+	if len(points) > 1 {
+		interval = i.pointer.path.Duration / time.Duration(len(points)-1)
+	}
+	i.pointer.mx.Unlock()
+	for n, p := range points {
+		if n > 0 { // pause between moves only, the pointer is already at target after the last one
+			i.s.Clock().Sleep(interval)
+		}
+		if err := i.MouseMove(MouseNone, p.X, p.Y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
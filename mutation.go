@@ -0,0 +1,91 @@
+package control
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecwid/control/protocol/runtime"
+	"github.com/ecwid/control/transport"
+)
+
+// MutationOptions kinds of DOM mutations to wait for, see MutationObserver.observe. ChildList is default
+type MutationOptions struct {
+	ChildList       bool          `json:"childList"`
+	Attributes      bool          `json:"attributes"`
+	CharacterData   bool          `json:"characterData"`
+	Subtree         bool          `json:"subtree"`
+	AttributeFilter []string      `json:"attributeFilter,omitempty"`
+	OldValue        bool          `json:"-"` // record previous value of attribute or character data
+	Timeout         time.Duration `json:"-"`
+}
+
+// MutationRecord the first mutation observed
+type MutationRecord struct {
+	ID            uint64 `json:"id"`
+	Type          string `json:"type"`   // childList, attributes or characterData
+	Target        string `json:"target"` // node name of mutated node
+	AddedNodes    int    `json:"added"`
+	RemovedNodes  int    `json:"removed"`
+	AttributeName string `json:"attribute"`
+	OldValue      string `json:"oldValue"`
+}
+
+type mutationInit struct {
+	MutationOptions
+	AttributeOldValue     bool `json:"attributeOldValue,omitempty"`
+	CharacterDataOldValue bool `json:"characterDataOldValue,omitempty"`
+}
+
+// WaitForMutation wait for mutation of the element matched by selector in main frame, see Element.WaitForMutation
+func (s Session) WaitForMutation(selector string, opts MutationOptions) (*MutationRecord, error) {
+	el, err := s.Page().QuerySelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return el.WaitForMutation(opts)
+}
+
+// WaitForMutation observe the element with MutationObserver and wait for the first mutation (e.g. a child was added)
+func (e Element) WaitForMutation(opts MutationOptions) (*MutationRecord, error) {
+	var s = e.frame.session
+	if !opts.ChildList && !opts.Attributes && !opts.CharacterData && len(opts.AttributeFilter) == 0 {
+		opts.ChildList = true
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = s.browser.Client.Timeout
+	}
+	if err := runtime.AddBinding(s, runtime.AddBindingArgs{Name: bindMutation}); err != nil {
+		return nil, err
+	}
+	var id = atomic.AddUint64(s.guid, 1)
+	future := s.Observe("Runtime.bindingCalled", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		var called = runtime.BindingCalled{}
+		if err := json.Unmarshal(value.Params, &called); err != nil || called.Name != bindMutation {
+			return
+		}
+		var record = &MutationRecord{}
+		if err := json.Unmarshal([]byte(called.Payload), record); err == nil && record.ID == id {
+			resolve(record)
+		}
+	})
+	defer future.Cancel()
+	var init = mutationInit{
+		MutationOptions:       opts,
+		AttributeOldValue:     opts.OldValue && (opts.Attributes || len(opts.AttributeFilter) > 0),
+		CharacterDataOldValue: opts.OldValue && opts.CharacterData,
+	}
+	observer, err := e.Handle().Call(functionObserveMutation, id, init)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = observer.callFunction(`function(){this.disconnect()}`, true)
+		_ = observer.Dispose()
+	}()
+	val, err := future.get(opts.Timeout, nil)
+	if err != nil {
+		return nil, err
+	}
+	return val.(*MutationRecord), nil
+}
@@ -0,0 +1,111 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+// lifecycle events reached by current document of the frames
+type loadStates struct {
+	mx     sync.Mutex
+	frames map[common.FrameId]map[LifecycleEventType]bool
+}
+
+func newLoadStates() *loadStates {
+	return &loadStates{frames: map[common.FrameId]map[LifecycleEventType]bool{}}
+}
+
+func (l *loadStates) update(v page.LifecycleEvent) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if v.Name == string(LifecycleInit) || l.frames[v.FrameId] == nil {
+		l.frames[v.FrameId] = map[LifecycleEventType]bool{}
+	}
+	l.frames[v.FrameId][LifecycleEventType(v.Name)] = true
+}
+
+func (l *loadStates) reached(frameID common.FrameId, event LifecycleEventType) bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	return l.frames[frameID][event]
+}
+
+// WaitForNavigation see Frame.WaitForNavigation
+func (s Session) WaitForNavigation(urlMatcher func(url string) bool, waitUntil LifecycleEventType) Future {
+	return s.Page().WaitForNavigation(urlMatcher, waitUntil)
+}
+
+// WaitForLoadState see Frame.WaitForLoadState
+func (s Session) WaitForLoadState(state LifecycleEventType, timeout time.Duration) error {
+	return s.Page().WaitForLoadState(state, timeout)
+}
+
+// WaitForNavigation returns future resolved by *page.Frame when the frame navigated to url matched by urlMatcher (nil matches any)
+// and reached waitUntil lifecycle event. Same-document navigations resolve immediately.
+// Call it before the action that triggers navigation:
+//
+//	nav := frame.WaitForNavigation(nil, LifecycleLoad)
+//	err = button.Click()
+//	_, err = nav.Get(timeout)
+func (f Frame) WaitForNavigation(urlMatcher func(url string) bool, waitUntil LifecycleEventType) Future {
+	var navigated *page.Frame
+	return f.session.Observe("*", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		switch value.Method {
+
+		case "Page.frameNavigated":
+			var v = page.FrameNavigated{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.Frame.Id == f.id && (urlMatcher == nil || urlMatcher(v.Frame.Url)) {
+				navigated = v.Frame
+			}
+
+		case "Page.navigatedWithinDocument":
+			var v = page.NavigatedWithinDocument{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.FrameId == f.id && (urlMatcher == nil || urlMatcher(v.Url)) {
+				resolve(&page.Frame{Id: v.FrameId, Url: v.Url})
+			}
+
+		case "Page.lifecycleEvent":
+			var v = page.LifecycleEvent{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if navigated != nil && v.FrameId == f.id && v.LoaderId == navigated.LoaderId && v.Name == string(waitUntil) {
+				resolve(navigated)
+			}
+		}
+	})
+}
+
+// WaitForLoadState wait until current document of the frame reached lifecycle event, returns immediately if it is already reached
+func (f Frame) WaitForLoadState(state LifecycleEventType, timeout time.Duration) error {
+	future := f.session.Observe("Page.lifecycleEvent", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		var v = page.LifecycleEvent{}
+		if err := json.Unmarshal(value.Params, &v); err != nil {
+			reject(err)
+			return
+		}
+		if v.FrameId == f.id && v.Name == string(state) {
+			resolve(v)
+		}
+	})
+	defer future.Cancel()
+	if f.session.loadStates.reached(f.id, state) {
+		return nil
+	}
+	_, err := future.Get(timeout)
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/ecwid/control/protocol/network"
 	"github.com/ecwid/control/transport"
@@ -49,6 +51,71 @@ func (s *Session) GetResponseReceived(predicate func(request *network.Request) b
 	})
 }
 
+// WaitForNetworkIdle wait until there are no more than maxInflight requests in flight for idleTime.
+// Only requests sent after the call are tracked
+func (s Session) WaitForNetworkIdle(idleTime time.Duration, maxInflight int, timeout time.Duration) error {
+	var (
+		mx       sync.Mutex
+		inflight = map[network.RequestId]bool{}
+		changed  = make(chan struct{}, 1)
+	)
+	cancel := s.Subscribe("*", func(value transport.Event) {
+		var request = struct {
+			RequestId network.RequestId `json:"requestId"`
+		}{}
+		switch value.Method {
+		case "Network.requestWillBeSent", "Network.loadingFinished", "Network.loadingFailed":
+			if err := json.Unmarshal(value.Params, &request); err != nil {
+				return
+			}
+		default:
+			return
+		}
+		mx.Lock()
+		if value.Method == "Network.requestWillBeSent" {
+			inflight[request.RequestId] = true
+		} else {
+			delete(inflight, request.RequestId)
+		}
+		mx.Unlock()
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer cancel()
+	var (
+		clock    = s.Clock()
+		deadline = clock.NewTimer(timeout)
+		idle     = clock.NewTimer(idleTime)
+	)
+	defer deadline.Stop()
+	defer idle.Stop()
+	for {
+		select {
+		case <-changed:
+			mx.Lock()
+			n := len(inflight)
+			mx.Unlock()
+			if !idle.Stop() {
+				select {
+				case <-idle.C():
+				default:
+				}
+			}
+			if n <= maxInflight {
+				idle.Reset(idleTime)
+			}
+		case <-idle.C():
+			return nil
+		case <-deadline.C():
+			return FutureTimeoutError{timeout: timeout}
+		case <-s.context.Done():
+			return s.context.Err()
+		}
+	}
+}
+
 type Network struct {
 	s *Session
 }
@@ -77,8 +144,25 @@ func (n Network) GetCookies(urls ...string) ([]*network.Cookie, error) {
 }
 
 // SetExtraHTTPHeaders Specifies whether to always send extra HTTP headers with the requests from this page.
+// BrowserContext.RunIDHeader and headers of current environment are always added if configured.
+// It replaces all headers set by SetExtraHeaders
 func (n Network) SetExtraHTTPHeaders(v map[string]string) error {
-	val := network.Headers(v)
+	n.s.extraHeaders.replace(v)
+	return n.applyExtraHeaders()
+}
+
+func (n Network) applyExtraHeaders() error {
+	headers := map[string]string{}
+	if env := n.s.browser.Environment(); env != nil {
+		headers = env.headers()
+	}
+	for name, value := range n.s.extraHeaders.get() {
+		headers[name] = value
+	}
+	if b := n.s.browser; b.RunID != "" && b.RunIDHeader != "" {
+		headers[b.RunIDHeader] = b.RunID
+	}
+	val := network.Headers(headers)
 	return network.SetExtraHTTPHeaders(n.s, network.SetExtraHTTPHeadersArgs{
 		Headers: &val,
 	})
@@ -112,6 +196,53 @@ func (n Network) EmulateNetworkConditions(offline bool, latency, downloadThrough
 	})
 }
 
+// NetworkConditions throttling profile, throughput is in bytes/sec (-1 disables throttling), latency in ms
+type NetworkConditions struct {
+	Offline            bool
+	Latency            float64
+	DownloadThroughput float64
+	UploadThroughput   float64
+	ConnectionType     network.ConnectionType
+}
+
+// Throttling presets of DevTools
+var (
+	NetworkSlow3G = NetworkConditions{
+		Latency:            2000,
+		DownloadThroughput: 500 * 1000 / 8 * 0.8,
+		UploadThroughput:   500 * 1000 / 8 * 0.8,
+		ConnectionType:     ConnectionTypeCellular3g,
+	}
+	NetworkFast3G = NetworkConditions{
+		Latency:            562.5,
+		DownloadThroughput: 1.6 * 1000 * 1000 / 8 * 0.9,
+		UploadThroughput:   750 * 1000 / 8 * 0.9,
+		ConnectionType:     ConnectionTypeCellular3g,
+	}
+	NetworkRegular4G = NetworkConditions{
+		Latency:            20,
+		DownloadThroughput: 4 * 1000 * 1000 / 8,
+		UploadThroughput:   3 * 1000 * 1000 / 8,
+		ConnectionType:     ConnectionTypeCellular4g,
+	}
+	NetworkDSL = NetworkConditions{
+		Latency:            5,
+		DownloadThroughput: 2 * 1000 * 1000 / 8,
+		UploadThroughput:   1 * 1000 * 1000 / 8,
+		ConnectionType:     ConnectionTypeEthernet,
+	}
+	NetworkNoThrottling = NetworkConditions{
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+		ConnectionType:     ConnectionTypeNone,
+	}
+)
+
+// EmulateConditions apply throttling profile (e.g. NetworkSlow3G) to requests of the session
+func (n Network) EmulateConditions(c NetworkConditions) error {
+	return n.EmulateNetworkConditions(c.Offline, c.Latency, c.DownloadThroughput, c.UploadThroughput, c.ConnectionType)
+}
+
 // SetBlockedURLs ...
 func (n Network) SetBlockedURLs(urls []string) error {
 	return network.SetBlockedURLs(n.s, network.SetBlockedURLsArgs{
@@ -119,6 +250,32 @@ func (n Network) SetBlockedURLs(urls []string) error {
 	})
 }
 
+// BlockURLs block requests which url matches any of patterns ('*' is zero or more characters),
+// e.g. "*google-analytics.com*", BlockURLs() without patterns unblocks all urls
+func (n Network) BlockURLs(patterns ...string) error {
+	if patterns == nil {
+		patterns = []string{}
+	}
+	return n.SetBlockedURLs(patterns)
+}
+
+// BlockResourceTypes fail requests of given resource types (e.g. "Image", "Font", "Media") with BlockedByClient
+// using interception, so it can be used along with other routes
+func (n Network) BlockResourceTypes(types ...network.ResourceType) (cancel func(), err error) {
+	if len(types) == 0 {
+		return func() {}, nil
+	}
+	return n.s.interceptTypes(StageRequest, types, func(route *Route) {
+		_ = route.Fail("BlockedByClient")
+	})
+}
+
+// SetMaxPostDataSize longest post body (in bytes) included in requestWillBeSent notification of the session,
+// 0 means DefaultMaxPostDataSize
+func (n Network) SetMaxPostDataSize(size int) error {
+	return network.Enable(n.s, n.s.browser.networkArgs(size))
+}
+
 // GetRequestPostData https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getRequestPostData
 func (n Network) GetRequestPostData(requestID network.RequestId) (string, error) {
 	val, err := network.GetRequestPostData(n.s, network.GetRequestPostDataArgs{
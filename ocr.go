@@ -0,0 +1,66 @@
+package control
+
+import (
+	"strings"
+	"sync"
+)
+
+// OCR recognizes text on PNG image, plug your engine by Session.SetOCR
+type OCR interface {
+	Recognize(png []byte) (text string, confidence float64, err error)
+}
+
+// RecognizedText text of element and confidence of recognition (1 if text is read from DOM)
+type RecognizedText struct {
+	Text       string
+	Confidence float64
+	OCR        bool // text was recognized by OCR
+}
+
+type noOCR struct{}
+
+func (noOCR) Recognize([]byte) (string, float64, error) {
+	return "", 0, nil
+}
+
+type ocrHolder struct {
+	mx  sync.Mutex
+	ocr OCR
+}
+
+func (o *ocrHolder) get() OCR {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	if o.ocr == nil {
+		return noOCR{}
+	}
+	return o.ocr
+}
+
+// SetOCR set OCR engine used by Element.GetTextWithOCR, nil resets to no-op
+func (s Session) SetOCR(ocr OCR) {
+	s.ocr.mx.Lock()
+	defer s.ocr.mx.Unlock()
+	s.ocr.ocr = ocr
+}
+
+// GetTextWithOCR get text of element, falls back to OCR of element screenshot
+// if there is no DOM text (e.g. canvas-rendered or image-based text)
+func (e Element) GetTextWithOCR() (RecognizedText, error) {
+	text, err := e.GetText()
+	if err != nil {
+		return RecognizedText{}, err
+	}
+	if strings.TrimSpace(text) != "" {
+		return RecognizedText{Text: text, Confidence: 1}, nil
+	}
+	png, err := e.CaptureScreenshot("png", 0)
+	if err != nil {
+		return RecognizedText{}, err
+	}
+	text, confidence, err := e.frame.session.ocr.get().Recognize(png)
+	if err != nil {
+		return RecognizedText{}, err
+	}
+	return RecognizedText{Text: text, Confidence: confidence, OCR: true}, nil
+}
@@ -0,0 +1,116 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+const resourceTypeDocument network.ResourceType = "Document"
+
+// OfflineResult how main frame documents were loaded while the page was offline
+type OfflineResult struct {
+	URL               string // url of the last document response
+	Status            int
+	FromServiceWorker bool   // the last document was served by service worker (e.g. offline fallback page)
+	ErrorText         string // error of the last failed document request (e.g. net::ERR_INTERNET_DISCONNECTED)
+}
+
+// ExpectOfflineBehavior switch the page and its workers (including service workers attached during the action) offline,
+// perform action (e.g. Reload or Navigate) and switch them back online.
+// Reports the last main frame document loaded during the action, error of action is returned along with the result
+func (s Session) ExpectOfflineBehavior(action func() error) (*OfflineResult, error) {
+	var (
+		mx        sync.Mutex
+		result    = &OfflineResult{}
+		main      = common.FrameId(s.tid)
+		documents = map[network.RequestId]bool{} // navigation requests of the main frame
+		offline   []*Session
+		restored  bool // workers attached after the action stay online
+	)
+	cancel := s.Subscribe("*", func(e transport.Event) {
+		switch e.Method {
+		case "Network.requestWillBeSent":
+			var v = network.RequestWillBeSent{}
+			if err := json.Unmarshal(e.Params, &v); err != nil || v.Type != resourceTypeDocument || v.FrameId != main {
+				return
+			}
+			mx.Lock()
+			documents[v.RequestId] = true
+			mx.Unlock()
+		case "Network.responseReceived":
+			var v = network.ResponseReceived{}
+			if err := json.Unmarshal(e.Params, &v); err != nil || v.Type != resourceTypeDocument || v.FrameId != main {
+				return
+			}
+			mx.Lock()
+			*result = OfflineResult{URL: v.Response.Url, Status: v.Response.Status, FromServiceWorker: v.Response.FromServiceWorker}
+			mx.Unlock()
+		case "Network.loadingFailed":
+			var v = network.LoadingFailed{}
+			if err := json.Unmarshal(e.Params, &v); err != nil {
+				return
+			}
+			mx.Lock()
+			if documents[v.RequestId] {
+				*result = OfflineResult{ErrorText: v.ErrorText}
+			}
+			mx.Unlock()
+		}
+	})
+	defer cancel()
+	var setOffline = func(session *Session) error {
+		if err := session.optional(session.Network.SetOffline(true)); err != nil {
+			return err
+		}
+		mx.Lock()
+		defer mx.Unlock()
+		if restored {
+			return session.Network.SetOffline(false)
+		}
+		offline = append(offline, session)
+		return nil
+	}
+	var online = func() error {
+		mx.Lock()
+		var list = offline
+		offline, restored = nil, true
+		mx.Unlock()
+		var err error
+		for _, session := range list {
+			if err1 := session.Network.SetOffline(false); err == nil && !session.IsClosed() {
+				err = err1
+			}
+		}
+		return err
+	}
+	cancelHook := s.OnWorkerAttached(func(w *Worker) {
+		_ = setOffline(w.Session)
+	})
+	defer cancelHook()
+	var err = setOffline(&s)
+	for _, w := range s.Workers() {
+		if err != nil {
+			break
+		}
+		if err = setOffline(w.Session); err != nil && w.IsClosed() {
+			err = nil // worker is gone
+		}
+	}
+	if err != nil {
+		_ = online()
+		return nil, err
+	}
+	err = action()
+	cancelHook()
+	if err1 := online(); err == nil {
+		err = err1
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	var r = *result
+	return &r, err
+}
@@ -0,0 +1,101 @@
+package control
+
+import (
+	"sync/atomic"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+)
+
+const targetTypeIframe = "iframe"
+
+// autoAttachArgs children are paused until attached() has set up their session,
+// so early activity of workers and frames (console, network, exceptions) is not missed
+var autoAttachArgs = target.SetAutoAttachArgs{
+	AutoAttach:             true,
+	WaitForDebuggerOnStart: true,
+	Flatten:                true,
+	Filter: target.TargetFilter{
+		{Type: targetTypeIframe},
+		{Type: targetTypePage}, // prerender
+		{Type: targetTypeWorker},
+		{Type: targetTypeSharedWorker},
+		{Type: targetTypeServiceWorker},
+		{Exclude: true},
+	},
+}
+
+// resume run child target paused on start by auto-attach
+func (s Session) resume(v target.AttachedToTarget) {
+	if v.WaitingForDebugger {
+		_ = s.browser.Client.Call(string(v.SessionId), "Runtime.runIfWaitingForDebugger", nil, nil)
+	}
+}
+
+// attached handle Target.attachedToTarget of auto-attached child target
+func (s Session) attached(v target.AttachedToTarget) {
+	s.children.Store(v.SessionId, true)
+	switch v.TargetInfo.Type {
+	case targetTypeIframe:
+		go func() {
+			defer s.resume(v)
+			child, err := s.browser.runSession(v.TargetInfo.TargetId, v.SessionId)
+			if err != nil {
+				return
+			}
+			var id = common.FrameId(v.TargetInfo.TargetId)
+			s.oopifs.Store(id, child)
+			// release the reference as soon as the child is gone
+			child.OnStateChange(func(_, to SessionState) {
+				if to.IsTerminal() {
+					s.oopifs.Delete(id)
+				}
+			})
+			if child.State().IsTerminal() { // detached before the hook is registered
+				s.oopifs.Delete(id)
+				return
+			}
+			s.oopifHooks.Range(func(_, hook interface{}) bool {
+				hook.(func(*Session))(child)
+				return true
+			})
+		}()
+	case targetTypePage:
+		if v.TargetInfo.Subtype == targetSubtypePrerender {
+			go s.attachPrerender(v)
+		} else {
+			go s.resume(v)
+		}
+	case targetTypeWorker, targetTypeSharedWorker, targetTypeServiceWorker:
+		go s.attachWorker(v)
+	default:
+		go s.resume(v)
+	}
+}
+
+// detached forward Target.detachedFromTarget of auto-attached child target (out-of-process iframe, worker)
+// to the child session, it's reported to the parent session only and the child can't terminate itself
+func (s Session) detached(v target.DetachedFromTarget, e transport.Event) {
+	if _, ok := s.children.LoadAndDelete(v.SessionId); ok {
+		// async: client holds the publisher lock while notifying the parent
+		go s.browser.Client.Notify(string(v.SessionId), e)
+	}
+}
+
+// onOOPIFAttached register hook called when out-of-process iframe is attached, before the frame is resumed
+func (s Session) onOOPIFAttached(hook func(*Session)) (cancel func()) {
+	var uid = atomic.AddUint64(s.guid, 1)
+	s.oopifHooks.Store(uid, hook)
+	return func() {
+		s.oopifHooks.Delete(uid)
+	}
+}
+
+// frame returns handle of the frame routed to the session that owns it (out-of-process iframes have own session)
+func (s *Session) frame(id common.FrameId) *Frame {
+	if val, ok := s.oopifs.Load(id); ok {
+		return &Frame{id: id, session: val.(*Session)}
+	}
+	return &Frame{id: id, session: s}
+}
@@ -0,0 +1,324 @@
+package control
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ecwid/control/protocol/fetch"
+)
+
+// openAPIMaxDepth limit of nested schemas generation (recursive schemas)
+const openAPIMaxDepth = 8
+
+type openAPIDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema   *openAPISchema `json:"schema"`
+			Example  interface{}    `json:"example"`
+			Examples map[string]struct {
+				Value interface{} `json:"value"`
+			} `json:"examples"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `json:"$ref"`
+	Type       string                    `json:"type"`
+	Format     string                    `json:"format"`
+	Example    interface{}               `json:"example"`
+	Default    interface{}               `json:"default"`
+	Enum       []interface{}             `json:"enum"`
+	Properties map[string]*openAPISchema `json:"properties"`
+	Items      *openAPISchema            `json:"items"`
+	AllOf      []*openAPISchema          `json:"allOf"`
+	OneOf      []*openAPISchema          `json:"oneOf"`
+	AnyOf      []*openAPISchema          `json:"anyOf"`
+	Minimum    *float64                  `json:"minimum"`
+}
+
+// MockResponse stub response of OpenAPI operation
+type MockResponse struct {
+	Method      string
+	Path        string // path template of the operation (e.g. /pets/{id})
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// OpenAPIMock interception handler which responds to the requests of OpenAPI operations with
+// examples of the document or values generated from response schemas
+type OpenAPIMock struct {
+	base       *url.URL
+	operations []openAPIRoute
+}
+
+type openAPIRoute struct {
+	path     *regexp.Regexp
+	response MockResponse
+}
+
+// NewOpenAPIMock builds stubs of all operations of OpenAPI 3 document (JSON).
+// baseURL overrides the first server of the document. Successful response is preferred (200, other 2xx, default),
+// JSON content is preferred, and example is taken from `example`, first of `examples` or generated from the schema
+func NewOpenAPIMock(document []byte, baseURL string) (*OpenAPIMock, error) {
+	var doc = openAPIDocument{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		if len(doc.Servers) == 0 {
+			return nil, ErrNoOpenAPIServer
+		}
+		baseURL = doc.Servers[0].URL
+	}
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	var mock = &OpenAPIMock{base: base}
+	for path, item := range doc.Paths {
+		for method, raw := range item {
+			switch method = strings.ToUpper(method); method {
+			case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+				http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace:
+			default:
+				continue // parameters, summary, servers etc
+			}
+			var op = openAPIOperation{}
+			if err = json.Unmarshal(raw, &op); err != nil {
+				return nil, err
+			}
+			var response = doc.response(op)
+			response.Method, response.Path = method, path
+			mock.operations = append(mock.operations, openAPIRoute{path: compilePathTemplate(base.Path + path), response: response})
+		}
+	}
+	// static paths win over templated ones (/pets/mine before /pets/{id})
+	sort.SliceStable(mock.operations, func(i, j int) bool {
+		return strings.Count(mock.operations[i].response.Path, "{") < strings.Count(mock.operations[j].response.Path, "{")
+	})
+	return mock, nil
+}
+
+// Responses stubs of all operations
+func (m OpenAPIMock) Responses() []MockResponse {
+	var list = make([]MockResponse, len(m.operations))
+	for i, v := range m.operations {
+		list[i] = v.response
+	}
+	return list
+}
+
+// Match stub response for method and url of the request
+func (m OpenAPIMock) Match(method, requestURL string) (MockResponse, bool) {
+	u, err := url.Parse(requestURL)
+	if err != nil || u.Host != m.base.Host || (m.base.Scheme != "" && u.Scheme != m.base.Scheme) {
+		return MockResponse{}, false
+	}
+	for _, v := range m.operations {
+		if v.response.Method == method && v.path.MatchString(u.Path) {
+			return v.response, true
+		}
+	}
+	return MockResponse{}, false
+}
+
+// Handler fulfills requests of known operations with their stubs, other requests are passed further
+func (m OpenAPIMock) Handler() RouteHandler {
+	return func(route *Route) {
+		response, ok := m.Match(route.Request.Method, route.Request.Url)
+		if !ok {
+			return
+		}
+		var headers = map[string]string{"Access-Control-Allow-Origin": "*"}
+		if response.ContentType != "" {
+			headers["Content-Type"] = response.ContentType
+		}
+		_ = route.Fulfill(response.Status, headers, response.Body)
+	}
+}
+
+// MockOpenAPI intercept requests to the server of OpenAPI document and respond with the stubs of its operations
+func (s Session) MockOpenAPI(document []byte, baseURL string) (cancel func(), err error) {
+	mock, err := NewOpenAPIMock(document, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.Intercept(fetch.RequestPattern{UrlPattern: mock.base.String() + "*"}, mock.Handler())
+}
+
+// compilePathTemplate `/pets/{id}` to `^/pets/[^/]+/?$`
+func compilePathTemplate(path string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start == -1 || end < start {
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(path[:start]))
+		b.WriteString("[^/]+")
+		path = path[end+1:]
+	}
+	b.WriteString(regexp.QuoteMeta(strings.TrimSuffix(path, "/")))
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String())
+}
+
+func (d openAPIDocument) response(op openAPIOperation) MockResponse {
+	var codes = make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if ri, rj := openAPIStatusRank(codes[i]), openAPIStatusRank(codes[j]); ri != rj {
+			return ri < rj
+		}
+		return codes[i] < codes[j]
+	})
+	var response = MockResponse{Status: http.StatusOK}
+	if len(codes) == 0 {
+		return response
+	}
+	if status, err := strconv.Atoi(codes[0]); err == nil {
+		response.Status = status
+	}
+	var content = op.Responses[codes[0]].Content
+	var types = make([]string, 0, len(content))
+	for t := range content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	sort.SliceStable(types, func(i, j int) bool {
+		return strings.Contains(types[i], "json") && !strings.Contains(types[j], "json")
+	})
+	if len(types) == 0 {
+		if response.Status == http.StatusOK {
+			response.Status = http.StatusNoContent
+		}
+		return response
+	}
+	var (
+		media   = content[types[0]]
+		example = media.Example
+	)
+	response.ContentType = types[0]
+	if example == nil && len(media.Examples) > 0 {
+		var names = make([]string, 0, len(media.Examples))
+		for name := range media.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		example = media.Examples[names[0]].Value
+	}
+	if example == nil && media.Schema != nil {
+		example = d.generate(media.Schema, 0)
+	}
+	if s, ok := example.(string); ok && !strings.Contains(response.ContentType, "json") {
+		response.Body = []byte(s)
+	} else {
+		response.Body, _ = json.Marshal(example)
+	}
+	return response
+}
+
+func openAPIStatusRank(code string) int {
+	switch {
+	case code == "200":
+		return 0
+	case strings.HasPrefix(code, "2"):
+		return 1
+	case code == "default":
+		return 2
+	}
+	return 3
+}
+
+func (d openAPIDocument) resolve(schema *openAPISchema) *openAPISchema {
+	for i := 0; schema != nil && schema.Ref != "" && i < openAPIMaxDepth; i++ {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// generate example value of the schema
+func (d openAPIDocument) generate(schema *openAPISchema, depth int) interface{} {
+	if schema = d.resolve(schema); schema == nil || depth > openAPIMaxDepth {
+		return nil
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		var merged = map[string]interface{}{}
+		for _, v := range schema.AllOf {
+			if m, ok := d.generate(v, depth+1).(map[string]interface{}); ok {
+				for key, value := range m {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return d.generate(schema.OneOf[0], depth+1)
+	case len(schema.AnyOf) > 0:
+		return d.generate(schema.AnyOf[0], depth+1)
+	}
+	switch schema.Type {
+	case "array":
+		if schema.Items == nil || depth == openAPIMaxDepth {
+			return []interface{}{}
+		}
+		return []interface{}{d.generate(schema.Items, depth+1)}
+	case "integer", "number":
+		if schema.Minimum != nil {
+			return *schema.Minimum
+		}
+		return 0
+	case "boolean":
+		return true
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "1970-01-01T00:00:00Z"
+		case "date":
+			return "1970-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		}
+		return "string"
+	}
+	if schema.Type == "object" || schema.Properties != nil {
+		var object = map[string]interface{}{}
+		for name, v := range schema.Properties {
+			object[name] = d.generate(v, depth+1)
+		}
+		return object
+	}
+	return nil
+}
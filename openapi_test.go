@@ -0,0 +1,145 @@
+package control
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func jsonString(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCompilePathTemplate(t *testing.T) {
+	var cases = []struct {
+		template string
+		path     string
+		match    bool
+	}{
+		{"/pets", "/pets", true},
+		{"/pets", "/pets/", true},
+		{"/pets/", "/pets", true},
+		{"/pets", "/pets/1", false},
+		{"/pets/{id}", "/pets/1", true},
+		{"/pets/{id}", "/pets/1/", true},
+		{"/pets/{id}", "/pets/", false},
+		{"/pets/{id}", "/pets/1/toys", false},
+		{"/pets/{id}/toys/{toy}", "/pets/1/toys/ball", true},
+		{"/v1.0/pets", "/v1x0/pets", false},
+		{"/files/{name}.json", "/files/report.json", true},
+		{"/files/{name}.json", "/files/report.xml", false},
+	}
+	for _, c := range cases {
+		if got := compilePathTemplate(c.template).MatchString(c.path); got != c.match {
+			t.Errorf("template `%s` path `%s`: expected match %v, got %v", c.template, c.path, c.match, got)
+		}
+	}
+}
+
+func TestOpenAPIResponseSelection(t *testing.T) {
+	const document = `{
+		"servers": [{"url": "https://api.example.com/v1/"}],
+		"components": {"schemas": {
+			"Pet": {"type": "object", "properties": {"id": {"type": "integer", "minimum": 1}, "tag": {"$ref": "#/components/schemas/Tag"}}},
+			"Tag": {"type": "string", "enum": ["cat", "dog"]}
+		}},
+		"paths": {
+			"/pets/{id}": {
+				"parameters": [],
+				"get": {"responses": {
+					"404": {"content": {"application/json": {"example": {"error": "not found"}}}},
+					"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+				}},
+				"delete": {"responses": {"200": {}}}
+			},
+			"/pets/mine": {
+				"get": {"responses": {
+					"default": {"content": {"application/json": {"example": "default"}}},
+					"201": {"content": {
+						"text/plain": {"example": "plain"},
+						"application/json": {"examples": {"b": {"value": "b"}, "a": {"value": "a"}}}
+					}}
+				}}
+			},
+			"/health": {
+				"get": {"responses": {"default": {"content": {"text/plain": {"example": "ok"}}}}}
+			}
+		}
+	}`
+	mock, err := NewOpenAPIMock([]byte(document), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases = []struct {
+		method      string
+		url         string
+		ok          bool
+		status      int
+		contentType string
+		body        string
+	}{
+		{"GET", "https://api.example.com/v1/pets/7", true, 200, "application/json", `{"id":1,"tag":"cat"}`},
+		{"GET", "https://api.example.com/v1/pets/mine", true, 201, "application/json", `"a"`},
+		{"DELETE", "https://api.example.com/v1/pets/7", true, 204, "", ""},
+		{"GET", "https://api.example.com/v1/health", true, 200, "text/plain", "ok"},
+		{"POST", "https://api.example.com/v1/pets/7", false, 0, "", ""},
+		{"GET", "https://api.example.com/pets/7", false, 0, "", ""},
+		{"GET", "http://api.example.com/v1/pets/7", false, 0, "", ""},
+		{"GET", "https://other.example.com/v1/pets/7", false, 0, "", ""},
+	}
+	for _, c := range cases {
+		response, ok := mock.Match(c.method, c.url)
+		if ok != c.ok {
+			t.Errorf("%s %s: expected match %v", c.method, c.url, c.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if response.Status != c.status || response.ContentType != c.contentType || string(response.Body) != c.body {
+			t.Errorf("%s %s: unexpected response %d `%s` `%s`", c.method, c.url, response.Status, response.ContentType, response.Body)
+		}
+	}
+}
+
+func TestOpenAPIGenerate(t *testing.T) {
+	var (
+		min = 5.0
+		doc = openAPIDocument{}
+	)
+	doc.Components.Schemas = map[string]*openAPISchema{
+		"Node": {Type: "object", Properties: map[string]*openAPISchema{"next": {Ref: "#/components/schemas/Node"}}},
+	}
+	var cases = []struct {
+		name   string
+		schema *openAPISchema
+		expect string
+	}{
+		{"example", &openAPISchema{Type: "string", Example: "x"}, `"x"`},
+		{"default", &openAPISchema{Type: "integer", Default: 3.0}, `3`},
+		{"enum", &openAPISchema{Type: "string", Enum: []interface{}{"a", "b"}}, `"a"`},
+		{"minimum", &openAPISchema{Type: "number", Minimum: &min}, `5`},
+		{"boolean", &openAPISchema{Type: "boolean"}, `true`},
+		{"date-time", &openAPISchema{Type: "string", Format: "date-time"}, `"1970-01-01T00:00:00Z"`},
+		{"array", &openAPISchema{Type: "array", Items: &openAPISchema{Type: "integer"}}, `[0]`},
+		{"allOf", &openAPISchema{AllOf: []*openAPISchema{
+			{Properties: map[string]*openAPISchema{"a": {Type: "integer"}}},
+			{Properties: map[string]*openAPISchema{"b": {Type: "boolean"}}},
+		}}, `{"a":0,"b":true}`},
+		{"oneOf", &openAPISchema{OneOf: []*openAPISchema{{Type: "string"}, {Type: "integer"}}}, `"string"`},
+		{"unknown ref", &openAPISchema{Ref: "#/components/schemas/Missing"}, `null`},
+	}
+	for _, c := range cases {
+		if got := jsonString(t, doc.generate(c.schema, 0)); got != c.expect {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expect, got)
+		}
+	}
+	// recursive schema is cut at openAPIMaxDepth
+	if got := jsonString(t, doc.generate(&openAPISchema{Ref: "#/components/schemas/Node"}, 0)); got == "" {
+		t.Error("recursive schema is not generated")
+	}
+}
@@ -1,6 +1,8 @@
 package control
 
 import (
+	"io"
+
 	"github.com/ecwid/control/protocol/browser"
 	"github.com/ecwid/control/protocol/page"
 )
@@ -20,6 +22,18 @@ func (s Session) CaptureScreenshot(format string, quality int, clip *page.Viewpo
 	return val.Data, nil
 }
 
+// CaptureMHTML write MHTML archive of the page (including iframes and resources) to w
+func (s Session) CaptureMHTML(w io.Writer) error {
+	val, err := page.CaptureSnapshot(s, page.CaptureSnapshotArgs{
+		Format: "mhtml",
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, val.Data)
+	return err
+}
+
 // AddScriptToEvaluateOnNewDocument https://chromedevtools.github.io/devtools-protocol/tot/Page#method-addScriptToEvaluateOnNewDocument
 func (s Session) AddScriptToEvaluateOnNewDocument(source string) (page.ScriptIdentifier, error) {
 	val, err := page.AddScriptToEvaluateOnNewDocument(s, page.AddScriptToEvaluateOnNewDocumentArgs{
@@ -28,23 +42,54 @@ func (s Session) AddScriptToEvaluateOnNewDocument(source string) (page.ScriptIde
 	if err != nil {
 		return "", err
 	}
+	s.scripts.Store(val.Identifier, source)
 	return val.Identifier, nil
 }
 
 // RemoveScriptToEvaluateOnNewDocument https://chromedevtools.github.io/devtools-protocol/tot/Page#method-removeScriptToEvaluateOnNewDocument
 func (s Session) RemoveScriptToEvaluateOnNewDocument(identifier page.ScriptIdentifier) error {
-	return page.RemoveScriptToEvaluateOnNewDocument(s, page.RemoveScriptToEvaluateOnNewDocumentArgs{
+	err := page.RemoveScriptToEvaluateOnNewDocument(s, page.RemoveScriptToEvaluateOnNewDocumentArgs{
 		Identifier: identifier,
 	})
+	if err != nil {
+		return err
+	}
+	s.scripts.Delete(identifier)
+	return nil
+}
+
+// GetScriptsToEvaluateOnNewDocument returns all scripts installed by AddScriptToEvaluateOnNewDocument
+func (s Session) GetScriptsToEvaluateOnNewDocument() map[page.ScriptIdentifier]string {
+	var scripts = map[page.ScriptIdentifier]string{}
+	s.scripts.Range(func(key, value interface{}) bool {
+		scripts[key.(page.ScriptIdentifier)] = value.(string)
+		return true
+	})
+	return scripts
+}
+
+// RemoveAllScriptsToEvaluateOnNewDocument ...
+func (s Session) RemoveAllScriptsToEvaluateOnNewDocument() error {
+	for id := range s.GetScriptsToEvaluateOnNewDocument() {
+		if err := s.RemoveScriptToEvaluateOnNewDocument(id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetDownloadBehavior https://chromedevtools.github.io/devtools-protocol/tot/Page#method-setDownloadBehavior
 func (s Session) SetDownloadBehavior(behavior string, downloadPath string, eventsEnabled bool) error {
-	return browser.SetDownloadBehavior(s, browser.SetDownloadBehaviorArgs{
+	err := browser.SetDownloadBehavior(s, browser.SetDownloadBehaviorArgs{
 		Behavior:      behavior,
 		DownloadPath:  downloadPath,
 		EventsEnabled: eventsEnabled, // default false
 	})
+	if err != nil {
+		return err
+	}
+	s.browser.downloads.Store(s.tid, downloadConfig{behavior: behavior, path: downloadPath})
+	return nil
 }
 
 // HandleJavaScriptDialog ...
@@ -55,6 +100,7 @@ func (s Session) HandleJavaScriptDialog(accept bool, promptText string) error {
 	})
 }
 
+// GetLayoutMetrics returns content size, layout and visual viewports (including scroll offsets) in CSS pixels
 func (s Session) GetLayoutMetrics() (*page.GetLayoutMetricsVal, error) {
 	view, err := page.GetLayoutMetrics(s)
 	if err != nil {
@@ -62,3 +108,27 @@ func (s Session) GetLayoutMetrics() (*page.GetLayoutMetricsVal, error) {
 	}
 	return view, nil
 }
+
+// ScrollPosition returns scroll offsets of the visual viewport relative to the document
+func (s Session) ScrollPosition() (x, y float64, err error) {
+	view, err := s.GetLayoutMetrics()
+	if err != nil {
+		return 0, 0, err
+	}
+	return view.CssVisualViewport.PageX, view.CssVisualViewport.PageY, nil
+}
+
+// CaptureFullPageScreenshot capture screenshot of the whole content of the page beyond the viewport
+func (s Session) CaptureFullPageScreenshot(format string, quality int) ([]byte, error) {
+	view, err := s.GetLayoutMetrics()
+	if err != nil {
+		return nil, err
+	}
+	return s.CaptureScreenshot(format, quality, &page.Viewport{
+		X:      view.CssContentSize.X,
+		Y:      view.CssContentSize.Y,
+		Width:  view.CssContentSize.Width,
+		Height: view.CssContentSize.Height,
+		Scale:  1,
+	}, true, true)
+}
@@ -0,0 +1,71 @@
+package control
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/ecwid/control/protocol/page"
+)
+
+const mimeTypePDF = "application/pdf"
+
+var ErrNotPDF = errors.New("document is not a PDF")
+
+// PDFDocument content of PDF document
+type PDFDocument struct {
+	Text  string
+	Pages int
+}
+
+// PDFParser extracts content of PDF document, plug any PDF library
+type PDFParser interface {
+	Parse(data []byte) (*PDFDocument, error)
+}
+
+func (f Frame) frameInfo() (*page.Frame, error) {
+	tree, err := f.frameTree()
+	if err != nil {
+		return nil, err
+	}
+	return tree.Frame, nil
+}
+
+// IsPDF true if the frame's document is PDF (opened in Chrome's PDF viewer)
+func (f Frame) IsPDF() (bool, error) {
+	info, err := f.frameInfo()
+	if err != nil {
+		return false, err
+	}
+	return info.MimeType == mimeTypePDF, nil
+}
+
+// GetPDF returns bytes of PDF document loaded in the frame
+func (f Frame) GetPDF() ([]byte, error) {
+	info, err := f.frameInfo()
+	if err != nil {
+		return nil, err
+	}
+	if info.MimeType != mimeTypePDF {
+		return nil, ErrNotPDF
+	}
+	val, err := page.GetResourceContent(f, page.GetResourceContentArgs{
+		FrameId: f.id,
+		Url:     info.Url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.Base64Encoded {
+		return base64.StdEncoding.DecodeString(val.Content)
+	}
+	return []byte(val.Content), nil
+}
+
+// ParsePDF extract content of PDF document loaded in the frame by parser
+func (f Frame) ParsePDF(parser PDFParser) (*PDFDocument, error) {
+	data, err := f.GetPDF()
+	if err != nil {
+		return nil, err
+	}
+	return parser.Parse(data)
+}
@@ -0,0 +1,63 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/browser"
+	"github.com/ecwid/control/protocol/common"
+)
+
+const (
+	PermissionNotifications           browser.PermissionType = "notifications"
+	PermissionGeolocation             browser.PermissionType = "geolocation"
+	PermissionVideoCapture            browser.PermissionType = "videoCapture" // camera
+	PermissionAudioCapture            browser.PermissionType = "audioCapture" // microphone
+	PermissionClipboardReadWrite      browser.PermissionType = "clipboardReadWrite"
+	PermissionClipboardSanitizedWrite browser.PermissionType = "clipboardSanitizedWrite"
+	PermissionMidi                    browser.PermissionType = "midi"
+	PermissionSensors                 browser.PermissionType = "sensors"
+	PermissionBackgroundSync          browser.PermissionType = "backgroundSync"
+	PermissionIdleDetection           browser.PermissionType = "idleDetection"
+	PermissionStorageAccess           browser.PermissionType = "storageAccess"
+	PermissionDisplayCapture          browser.PermissionType = "displayCapture"
+)
+
+const (
+	PermissionGranted browser.PermissionSetting = "granted"
+	PermissionDenied  browser.PermissionSetting = "denied"
+	PermissionPrompt  browser.PermissionSetting = "prompt"
+)
+
+// GrantPermissions grant permissions to the origin (all origins if empty) in the default browser context,
+// other permissions of the origin are denied so the page never shows permission prompts
+func (b BrowserContext) GrantPermissions(origin string, permissions ...browser.PermissionType) error {
+	return b.GrantPermissionsIn("", origin, permissions...)
+}
+
+// GrantPermissionsIn grant permissions to the origin (all origins if empty) in the browser context
+func (b BrowserContext) GrantPermissionsIn(contextID common.BrowserContextID, origin string, permissions ...browser.PermissionType) error {
+	return browser.GrantPermissions(b, browser.GrantPermissionsArgs{
+		Permissions:      permissions,
+		Origin:           origin,
+		BrowserContextId: contextID,
+	})
+}
+
+// SetPermission set permission state (PermissionGranted, PermissionDenied or PermissionPrompt) of the origin
+// in the browser context (empty id means default context), name is name of permission descriptor (e.g. "camera")
+func (b BrowserContext) SetPermission(contextID common.BrowserContextID, origin, name string, setting browser.PermissionSetting) error {
+	return browser.SetPermission(b, browser.SetPermissionArgs{
+		Permission:       &browser.PermissionDescriptor{Name: name},
+		Setting:          setting,
+		Origin:           origin,
+		BrowserContextId: contextID,
+	})
+}
+
+// ResetPermissions reset all permission overrides of the default browser context
+func (b BrowserContext) ResetPermissions() error {
+	return b.ResetPermissionsIn("")
+}
+
+// ResetPermissionsIn reset all permission overrides of the browser context
+func (b BrowserContext) ResetPermissionsIn(contextID common.BrowserContextID) error {
+	return browser.ResetPermissions(b, browser.ResetPermissionsArgs{BrowserContextId: contextID})
+}
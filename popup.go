@@ -0,0 +1,53 @@
+package control
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+)
+
+// ExpectPopup performs action (e.g. click on target=_blank link) and waits for a page opened by this session,
+// returns attached and ready session of the popup. In Constrained mode target discovering is enabled
+// for the time of the call only
+func (s Session) ExpectPopup(action func() error, timeout time.Duration) (*Session, error) {
+	var known = map[target.TargetID]bool{}
+	if s.browser.Constrained {
+		// discovering reports existing targets too, previous popups of the page must not match
+		targets, err := s.browser.GetTargets()
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range targets {
+			known[t.TargetId] = true
+		}
+	}
+	future := s.Observe("Target.targetCreated", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		var v = target.TargetCreated{}
+		if err := json.Unmarshal(value.Params, &v); err != nil {
+			reject(err)
+			return
+		}
+		if v.TargetInfo.OpenerId == s.tid && v.TargetInfo.Type == targetTypePage && !known[v.TargetInfo.TargetId] {
+			resolve(v.TargetInfo)
+		}
+	})
+	defer future.Cancel()
+	if s.browser.Constrained {
+		if err := target.SetDiscoverTargets(s, target.SetDiscoverTargetsArgs{Discover: true}); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = target.SetDiscoverTargets(s, target.SetDiscoverTargetsArgs{Discover: false})
+		}()
+	}
+	if err := action(); err != nil {
+		return nil, err
+	}
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return s.browser.AttachPageTarget(val.(*target.TargetInfo).TargetId)
+}
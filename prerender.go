@@ -0,0 +1,139 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ecwid/control/protocol/preload"
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	targetSubtypePrerender   = "prerender"
+	prerenderStatusActivated = "Activated"
+)
+
+type prerenders struct {
+	pending   *sync.Map    // sessions of prerendered pages by target id
+	successor atomic.Value // *Session of activated prerender which replaced the page
+	mx        sync.Mutex
+	seq       uint64
+	hooks     map[uint64]func(*Session)
+}
+
+func newPrerenders() *prerenders {
+	return &prerenders{pending: &sync.Map{}, hooks: map[uint64]func(*Session){}}
+}
+
+// current session of the page, the session of activated prerender if the page was replaced
+func (s Session) current() *Session {
+	var current = &s
+	for {
+		next, _ := current.prerender.successor.Load().(*Session)
+		if next == nil {
+			return current
+		}
+		current = next
+	}
+}
+
+func (s Session) attachPrerender(v target.AttachedToTarget) {
+	defer s.resume(v)
+	child, err := s.browser.runSession(v.TargetInfo.TargetId, v.SessionId)
+	if err != nil {
+		return
+	}
+	s.prerender.pending.Store(v.TargetInfo.TargetId, child)
+	child.OnStateChange(func(_, to SessionState) {
+		if to.IsTerminal() {
+			s.prerender.pending.Delete(v.TargetInfo.TargetId)
+		}
+	})
+}
+
+// observePrerender detects activation of prerendered page by target info change (subtype is cleared)
+// or by completed prerender attempt
+func (s Session) observePrerender(e transport.Event) {
+	switch e.Method {
+	case "Target.targetInfoChanged":
+		var v = target.TargetInfoChanged{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.TargetInfo.Subtype != "" {
+			return
+		}
+		if val, ok := s.prerender.pending.Load(v.TargetInfo.TargetId); ok {
+			s.activatePrerender(val.(*Session))
+		}
+	case "Preload.prerenderAttemptCompleted":
+		var v = preload.PrerenderAttemptCompleted{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.FinalStatus != prerenderStatusActivated {
+			return
+		}
+		s.prerender.pending.Range(func(key, value interface{}) bool {
+			var child = value.(*Session)
+			if info, err := s.browser.targetInfo(child.tid); err == nil && info.Url == v.PrerenderingUrl {
+				s.activatePrerender(child)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// activatePrerender the session becomes a proxy of activated prerender: calls are routed to it
+// and its events are delivered to the observers of the session
+func (s Session) activatePrerender(child *Session) {
+	if _, loaded := s.prerender.pending.Load(child.tid); !loaded {
+		return
+	}
+	s.prerender.pending.Delete(child.tid)
+	s.prerender.successor.Store(child)
+	child.Subscribe("*", func(e transport.Event) {
+		s.publisher.Notify(e.Method, e)
+	})
+	s.prerender.mx.Lock()
+	var hooks = make([]func(*Session), 0, len(s.prerender.hooks))
+	for _, h := range s.prerender.hooks {
+		hooks = append(hooks, h)
+	}
+	s.prerender.mx.Unlock()
+	for _, h := range hooks {
+		go h(child)
+	}
+}
+
+// Prerenders returns sessions of pages prerendered by this page and not activated yet
+func (s Session) Prerenders() []*Session {
+	var list []*Session
+	s.prerender.pending.Range(func(key, value interface{}) bool {
+		list = append(list, value.(*Session))
+		return true
+	})
+	return list
+}
+
+// OnPrerenderActivated register hook called when prerendered page replaced this page,
+// since then the session transparently routes calls and events to the activated session
+func (s Session) OnPrerenderActivated(hook func(activated *Session)) (cancel func()) {
+	s.prerender.mx.Lock()
+	s.prerender.seq++
+	var id = s.prerender.seq
+	s.prerender.hooks[id] = hook
+	s.prerender.mx.Unlock()
+	return func() {
+		s.prerender.mx.Lock()
+		delete(s.prerender.hooks, id)
+		s.prerender.mx.Unlock()
+	}
+}
+
+// OnPrerenderStatus subscribe to status updates of prerender attempts (Preload domain)
+func (s Session) OnPrerenderStatus(handler func(preload.PrerenderStatusUpdated)) (cancel func()) {
+	return s.Subscribe("Preload.prerenderStatusUpdated", func(e transport.Event) {
+		var v = preload.PrerenderStatusUpdated{}
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			handler(v)
+		}
+	})
+}
@@ -13,6 +13,7 @@ const (
 	promisePending  = 0
 	promiseResolved = 1
 	promiseRejected = 2
+	promiseCanceled = 3
 )
 
 // a read-only view of promise
@@ -22,26 +23,38 @@ type Future struct {
 
 type promise struct {
 	once       *sync.Once
+	mx         *sync.Mutex // guards sends against close of done and err
 	context    context.Context
 	done       chan interface{}
 	err        chan error
 	cancelFunc func()
 	state      *int32
+	clock      transport.Clock
+	pageErrors *pageErrors
 }
 
 func (u promise) resolve(val interface{}) {
-	select {
-	case u.done <- val:
-		atomic.StoreInt32(u.state, promiseResolved)
-	default:
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	if atomic.CompareAndSwapInt32(u.state, promisePending, promiseResolved) {
+		u.done <- val
 	}
 }
 
 func (u promise) reject(err error) {
-	select {
-	case u.err <- err:
-		atomic.StoreInt32(u.state, promiseRejected)
-	default:
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	if atomic.CompareAndSwapInt32(u.state, promisePending, promiseRejected) {
+		u.err <- err
+	}
+}
+
+// keep put the received result back for the next Get unless the promise is canceled
+func (u promise) keep(put func()) {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	if atomic.LoadInt32(u.state) != promiseCanceled {
+		put()
 	}
 }
 
@@ -51,11 +64,14 @@ func (u promise) isPending() bool {
 
 func (u promise) cancel() {
 	u.once.Do(func() {
-		close(u.done)
-		close(u.err)
 		if u.cancelFunc != nil {
 			u.cancelFunc()
 		}
+		u.mx.Lock()
+		defer u.mx.Unlock()
+		atomic.StoreInt32(u.state, promiseCanceled)
+		close(u.done)
+		close(u.err)
 	})
 }
 
@@ -63,20 +79,33 @@ func (u Future) Cancel() {
 	u.promise.cancel()
 }
 
+// Get wait for the future to be resolved or rejected, in fail-fast mode (see SetFailOnPageError)
+// it fails with PageError as soon as uncaught exception appears on the page
 func (u Future) Get(timeout time.Duration) (interface{}, error) {
+	return u.get(timeout, u.promise.pageErrors.failFastC())
+}
+
+// get wait for the future regardless of page errors, it's used by internal waits (e.g. lifecycle of Navigate)
+func (u Future) get(timeout time.Duration, failed <-chan struct{}) (interface{}, error) {
 	defer u.Cancel()
-	var timer = time.NewTimer(timeout)
+	var timer = u.promise.clock.NewTimer(timeout)
 	defer timer.Stop()
 	select {
-	case val := <-u.promise.done:
-		u.promise.done <- val
+	case val, ok := <-u.promise.done:
+		if ok {
+			u.promise.keep(func() { u.promise.done <- val })
+		}
 		return val, nil
-	case err := <-u.promise.err:
-		u.promise.err <- err
+	case err, ok := <-u.promise.err:
+		if ok {
+			u.promise.keep(func() { u.promise.err <- err })
+		}
 		return nil, err
 	case <-u.promise.context.Done():
 		return nil, u.promise.context.Err()
-	case <-timer.C:
+	case <-failed:
+		return nil, u.promise.pageErrors.last()
+	case <-timer.C():
 		return nil, FutureTimeoutError{timeout: timeout}
 	}
 }
@@ -84,11 +113,14 @@ func (u Future) Get(timeout time.Duration) (interface{}, error) {
 func (s Session) Observe(method string, condition func(transport.Event, func(interface{}), func(error))) Future {
 	var state int32 = promisePending
 	u := &promise{
-		context: s.context,
-		state:   &state,
-		once:    &sync.Once{},
-		done:    make(chan interface{}, 1),
-		err:     make(chan error, 1),
+		context:    s.context,
+		clock:      s.Clock(),
+		pageErrors: s.pageErrors,
+		state:      &state,
+		once:       &sync.Once{},
+		mx:         &sync.Mutex{},
+		done:       make(chan interface{}, 1),
+		err:        make(chan error, 1),
 	}
 	u.cancelFunc = s.Subscribe(method, func(e transport.Event) {
 		if u.isPending() {
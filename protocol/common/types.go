@@ -21,12 +21,15 @@ Missing optional values will be filled in by the target with what it would norma
 */
 type UserAgentMetadata struct {
 	Brands          []*UserAgentBrandVersion `json:"brands,omitempty"`
+	FullVersionList []*UserAgentBrandVersion `json:"fullVersionList,omitempty"`
 	FullVersion     string                   `json:"fullVersion,omitempty"`
 	Platform        string                   `json:"platform"`
 	PlatformVersion string                   `json:"platformVersion"`
 	Architecture    string                   `json:"architecture"`
 	Model           string                   `json:"model"`
 	Mobile          bool                     `json:"mobile"`
+	Bitness         string                   `json:"bitness,omitempty"`
+	Wow64           bool                     `json:"wow64,omitempty"`
 }
 
 /*
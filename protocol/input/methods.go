@@ -26,6 +26,15 @@ func InsertText(c protocol.Caller, args InsertTextArgs) error {
 	return c.Call("Input.insertText", args, nil)
 }
 
+/*
+	This method sets the current candidate text for ime.
+Use imeCommitComposition to commit the final text.
+Use imeSetComposition with empty string as text to cancel composition.
+*/
+func ImeSetComposition(c protocol.Caller, args ImeSetCompositionArgs) error {
+	return c.Call("Input.imeSetComposition", args, nil)
+}
+
 /*
 	Dispatches a mouse event to the page.
 */
@@ -84,6 +84,14 @@ type InsertTextArgs struct {
 	Text string `json:"text"`
 }
 
+type ImeSetCompositionArgs struct {
+	Text             string `json:"text"`
+	SelectionStart   int    `json:"selectionStart"`
+	SelectionEnd     int    `json:"selectionEnd"`
+	ReplacementStart int    `json:"replacementStart,omitempty"`
+	ReplacementEnd   int    `json:"replacementEnd,omitempty"`
+}
+
 type DispatchMouseEventArgs struct {
 	Type               string                `json:"type"`
 	X                  float64               `json:"x"`
@@ -300,6 +300,7 @@ type Cookie struct {
 	SameParty    bool               `json:"sameParty"`
 	SourceScheme CookieSourceScheme `json:"sourceScheme"`
 	SourcePort   int                `json:"sourcePort"`
+	PartitionKey string             `json:"partitionKey,omitempty"`
 }
 
 /*
@@ -346,6 +347,7 @@ type CookieParam struct {
 	SameParty    bool                  `json:"sameParty,omitempty"`
 	SourceScheme CookieSourceScheme    `json:"sourceScheme,omitempty"`
 	SourcePort   int                   `json:"sourcePort,omitempty"`
+	PartitionKey string                `json:"partitionKey,omitempty"`
 }
 
 /*
@@ -522,10 +524,11 @@ type SetAcceptedEncodingsArgs struct {
 }
 
 type DeleteCookiesArgs struct {
-	Name   string `json:"name"`
-	Url    string `json:"url,omitempty"`
-	Domain string `json:"domain,omitempty"`
-	Path   string `json:"path,omitempty"`
+	Name         string `json:"name"`
+	Url          string `json:"url,omitempty"`
+	Domain       string `json:"domain,omitempty"`
+	Path         string `json:"path,omitempty"`
+	PartitionKey string `json:"partitionKey,omitempty"`
 }
 
 type EmulateNetworkConditionsArgs struct {
@@ -637,6 +640,7 @@ type SetCookieArgs struct {
 	SameParty    bool                  `json:"sameParty,omitempty"`
 	SourceScheme CookieSourceScheme    `json:"sourceScheme,omitempty"`
 	SourcePort   int                   `json:"sourcePort,omitempty"`
+	PartitionKey string                `json:"partitionKey,omitempty"`
 }
 
 type SetCookiesArgs struct {
@@ -44,7 +44,8 @@ type FrameDetached struct {
 	Fired once navigation of the frame has completed. Frame is now associated with the new loader.
 */
 type FrameNavigated struct {
-	Frame *Frame `json:"frame"`
+	Frame *Frame         `json:"frame"`
+	Type  NavigationType `json:"type,omitempty"`
 }
 
 /*
@@ -175,3 +176,15 @@ type CompilationCacheProduced struct {
 	Url  string `json:"url"`
 	Data []byte `json:"data"`
 }
+
+/*
+	Fired for failed bfcache history navigations if BackForwardCache feature is enabled. Do
+not assume any ordering with the Page.frameNavigated event. This event is fired only for
+main-frame history navigation where the document changes (non-same-document navigations),
+when bfcache navigation fails.
+*/
+type BackForwardCacheNotUsed struct {
+	LoaderId                network.LoaderId                          `json:"loaderId"`
+	FrameId                 common.FrameId                            `json:"frameId"`
+	NotRestoredExplanations []*BackForwardCacheNotRestoredExplanation `json:"notRestoredExplanations"`
+}
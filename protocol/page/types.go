@@ -483,3 +483,27 @@ type GenerateTestReportArgs struct {
 type SetInterceptFileChooserDialogArgs struct {
 	Enabled bool `json:"enabled"`
 }
+
+/*
+	The type of a frameNavigated event.
+*/
+type NavigationType string
+
+/*
+	List of not restored reasons for back-forward cache.
+*/
+type BackForwardCacheNotRestoredReason string
+
+/*
+	Types of not restored reasons for back-forward cache.
+*/
+type BackForwardCacheNotRestoredReasonType string
+
+/*
+
+ */
+type BackForwardCacheNotRestoredExplanation struct {
+	Type    BackForwardCacheNotRestoredReasonType `json:"type"`
+	Reason  BackForwardCacheNotRestoredReason     `json:"reason"`
+	Context string                                `json:"context,omitempty"`
+}
@@ -0,0 +1,40 @@
+package preload
+
+import (
+	"github.com/ecwid/control/protocol/common"
+)
+
+/*
+	Upsert. Currently, it is only emitted when a rule set added.
+*/
+type RuleSetUpdated struct {
+	RuleSet *RuleSet `json:"ruleSet"`
+}
+
+/*
+
+ */
+type RuleSetRemoved struct {
+	Id RuleSetId `json:"id"`
+}
+
+/*
+	Fired when a prerender attempt is completed.
+*/
+type PrerenderAttemptCompleted struct {
+	Key                 *PreloadingAttemptKey `json:"key"`
+	InitiatingFrameId   common.FrameId        `json:"initiatingFrameId"`
+	PrerenderingUrl     string                `json:"prerenderingUrl"`
+	FinalStatus         PrerenderFinalStatus  `json:"finalStatus"`
+	DisallowedApiMethod string                `json:"disallowedApiMethod,omitempty"`
+}
+
+/*
+	Fired when a prerender attempt is updated.
+*/
+type PrerenderStatusUpdated struct {
+	Key                     *PreloadingAttemptKey `json:"key"`
+	Status                  PreloadingStatus      `json:"status"`
+	PrerenderStatus         PrerenderFinalStatus  `json:"prerenderStatus,omitempty"`
+	DisallowedMojoInterface string                `json:"disallowedMojoInterface,omitempty"`
+}
@@ -0,0 +1,52 @@
+package preload
+
+import (
+	"github.com/ecwid/control/protocol/network"
+)
+
+/*
+	Unique id
+*/
+type RuleSetId string
+
+/*
+	Corresponds to SpeculationRuleSet
+*/
+type RuleSet struct {
+	Id         RuleSetId        `json:"id"`
+	LoaderId   network.LoaderId `json:"loaderId"`
+	SourceText string           `json:"sourceText"`
+}
+
+/*
+	The type of preloading attempted. It corresponds to
+mojom::SpeculationAction (although PrefetchWithSubresources is omitted as it
+isn't being used by clients).
+*/
+type SpeculationAction string
+
+/*
+	Corresponds to mojom::SpeculationTargetHint.
+*/
+type SpeculationTargetHint string
+
+/*
+	A key that identifies a preloading attempt.
+*/
+type PreloadingAttemptKey struct {
+	LoaderId   network.LoaderId      `json:"loaderId"`
+	Action     SpeculationAction     `json:"action"`
+	Url        string                `json:"url"`
+	TargetHint SpeculationTargetHint `json:"targetHint,omitempty"`
+}
+
+/*
+	Preloading status values, see also PreloadingTriggeringOutcome. This
+status is shared by prefetchStatusUpdated and prerenderStatusUpdated.
+*/
+type PreloadingStatus string
+
+/*
+	List of FinalStatus reasons for Prerender2.
+*/
+type PrerenderFinalStatus string
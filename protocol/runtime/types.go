@@ -20,15 +20,35 @@ type UnserializableValue string
 	Mirror object referencing original JavaScript object.
 */
 type RemoteObject struct {
-	Type                string              `json:"type"`
-	Subtype             string              `json:"subtype,omitempty"`
-	ClassName           string              `json:"className,omitempty"`
-	Value               interface{}         `json:"value,omitempty"`
-	UnserializableValue UnserializableValue `json:"unserializableValue,omitempty"`
-	Description         string              `json:"description,omitempty"`
-	ObjectId            RemoteObjectId      `json:"objectId,omitempty"`
-	Preview             *ObjectPreview      `json:"preview,omitempty"`
-	CustomPreview       *CustomPreview      `json:"customPreview,omitempty"`
+	Type                string               `json:"type"`
+	Subtype             string               `json:"subtype,omitempty"`
+	ClassName           string               `json:"className,omitempty"`
+	Value               interface{}          `json:"value,omitempty"`
+	UnserializableValue UnserializableValue  `json:"unserializableValue,omitempty"`
+	Description         string               `json:"description,omitempty"`
+	ObjectId            RemoteObjectId       `json:"objectId,omitempty"`
+	Preview             *ObjectPreview       `json:"preview,omitempty"`
+	CustomPreview       *CustomPreview       `json:"customPreview,omitempty"`
+	DeepSerializedValue *DeepSerializedValue `json:"deepSerializedValue,omitempty"`
+}
+
+/*
+	Represents options for serialization. Overrides `generatePreview` and `returnByValue`.
+*/
+type SerializationOptions struct {
+	Serialization        string      `json:"serialization"`
+	MaxDepth             int         `json:"maxDepth,omitempty"`
+	AdditionalParameters interface{} `json:"additionalParameters,omitempty"`
+}
+
+/*
+	Represents deep serialized value.
+*/
+type DeepSerializedValue struct {
+	Type                     string      `json:"type"`
+	Value                    interface{} `json:"value,omitempty"`
+	ObjectId                 string      `json:"objectId,omitempty"`
+	WeakLocalObjectReference int         `json:"weakLocalObjectReference,omitempty"`
 }
 
 /*
@@ -203,16 +223,17 @@ type AwaitPromiseVal struct {
 }
 
 type CallFunctionOnArgs struct {
-	FunctionDeclaration string             `json:"functionDeclaration"`
-	ObjectId            RemoteObjectId     `json:"objectId,omitempty"`
-	Arguments           []*CallArgument    `json:"arguments,omitempty"`
-	Silent              bool               `json:"silent,omitempty"`
-	ReturnByValue       bool               `json:"returnByValue,omitempty"`
-	GeneratePreview     bool               `json:"generatePreview,omitempty"`
-	UserGesture         bool               `json:"userGesture,omitempty"`
-	AwaitPromise        bool               `json:"awaitPromise,omitempty"`
-	ExecutionContextId  ExecutionContextId `json:"executionContextId,omitempty"`
-	ObjectGroup         string             `json:"objectGroup,omitempty"`
+	FunctionDeclaration  string                `json:"functionDeclaration"`
+	ObjectId             RemoteObjectId        `json:"objectId,omitempty"`
+	Arguments            []*CallArgument       `json:"arguments,omitempty"`
+	Silent               bool                  `json:"silent,omitempty"`
+	ReturnByValue        bool                  `json:"returnByValue,omitempty"`
+	GeneratePreview      bool                  `json:"generatePreview,omitempty"`
+	UserGesture          bool                  `json:"userGesture,omitempty"`
+	AwaitPromise         bool                  `json:"awaitPromise,omitempty"`
+	ExecutionContextId   ExecutionContextId    `json:"executionContextId,omitempty"`
+	ObjectGroup          string                `json:"objectGroup,omitempty"`
+	SerializationOptions *SerializationOptions `json:"serializationOptions,omitempty"`
 }
 
 type CallFunctionOnVal struct {
@@ -233,21 +254,22 @@ type CompileScriptVal struct {
 }
 
 type EvaluateArgs struct {
-	Expression                  string             `json:"expression"`
-	ObjectGroup                 string             `json:"objectGroup,omitempty"`
-	IncludeCommandLineAPI       bool               `json:"includeCommandLineAPI,omitempty"`
-	Silent                      bool               `json:"silent,omitempty"`
-	ContextId                   ExecutionContextId `json:"contextId,omitempty"`
-	ReturnByValue               bool               `json:"returnByValue,omitempty"`
-	GeneratePreview             bool               `json:"generatePreview,omitempty"`
-	UserGesture                 bool               `json:"userGesture,omitempty"`
-	AwaitPromise                bool               `json:"awaitPromise,omitempty"`
-	ThrowOnSideEffect           bool               `json:"throwOnSideEffect,omitempty"`
-	Timeout                     TimeDelta          `json:"timeout,omitempty"`
-	DisableBreaks               bool               `json:"disableBreaks,omitempty"`
-	ReplMode                    bool               `json:"replMode,omitempty"`
-	AllowUnsafeEvalBlockedByCSP bool               `json:"allowUnsafeEvalBlockedByCSP,omitempty"`
-	UniqueContextId             string             `json:"uniqueContextId,omitempty"`
+	Expression                  string                `json:"expression"`
+	ObjectGroup                 string                `json:"objectGroup,omitempty"`
+	IncludeCommandLineAPI       bool                  `json:"includeCommandLineAPI,omitempty"`
+	Silent                      bool                  `json:"silent,omitempty"`
+	ContextId                   ExecutionContextId    `json:"contextId,omitempty"`
+	ReturnByValue               bool                  `json:"returnByValue,omitempty"`
+	GeneratePreview             bool                  `json:"generatePreview,omitempty"`
+	UserGesture                 bool                  `json:"userGesture,omitempty"`
+	AwaitPromise                bool                  `json:"awaitPromise,omitempty"`
+	ThrowOnSideEffect           bool                  `json:"throwOnSideEffect,omitempty"`
+	Timeout                     TimeDelta             `json:"timeout,omitempty"`
+	DisableBreaks               bool                  `json:"disableBreaks,omitempty"`
+	ReplMode                    bool                  `json:"replMode,omitempty"`
+	AllowUnsafeEvalBlockedByCSP bool                  `json:"allowUnsafeEvalBlockedByCSP,omitempty"`
+	UniqueContextId             string                `json:"uniqueContextId,omitempty"`
+	SerializationOptions        *SerializationOptions `json:"serializationOptions,omitempty"`
 }
 
 type EvaluateVal struct {
@@ -27,6 +27,7 @@ type TargetInfo struct {
 	CanAccessOpener  bool                    `json:"canAccessOpener"`
 	OpenerFrameId    common.FrameId          `json:"openerFrameId,omitempty"`
 	BrowserContextId common.BrowserContextID `json:"browserContextId,omitempty"`
+	Subtype          string                  `json:"subtype,omitempty"`
 }
 
 /*
@@ -37,6 +38,24 @@ type RemoteLocation struct {
 	Port int    `json:"port"`
 }
 
+/*
+	A filter used by target query/discovery/auto-attach operations.
+*/
+type FilterEntry struct {
+	Exclude bool   `json:"exclude,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+/*
+	The entries in TargetFilter are matched sequentially against targets and
+the first entry that matches determines if the target is included or not,
+depending on the value of `exclude` field in the entry.
+If filter is not specified, the one assumed is
+[{type: "browser", exclude: true}, {type: "tab", exclude: true}, {}]
+(i.e. include everything but `browser` and `tab`).
+*/
+type TargetFilter []*FilterEntry
+
 type ActivateTargetArgs struct {
 	TargetId TargetID `json:"targetId"`
 }
@@ -112,9 +131,10 @@ type GetTargetsVal struct {
 }
 
 type SetAutoAttachArgs struct {
-	AutoAttach             bool `json:"autoAttach"`
-	WaitForDebuggerOnStart bool `json:"waitForDebuggerOnStart"`
-	Flatten                bool `json:"flatten,omitempty"`
+	AutoAttach             bool         `json:"autoAttach"`
+	WaitForDebuggerOnStart bool         `json:"waitForDebuggerOnStart"`
+	Flatten                bool         `json:"flatten,omitempty"`
+	Filter                 TargetFilter `json:"filter,omitempty"`
 }
 
 type SetDiscoverTargetsArgs struct {
@@ -0,0 +1,146 @@
+package control
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+)
+
+// StaticResourceTypes resource types recorded and replayed by default
+var StaticResourceTypes = []network.ResourceType{"Script", "Stylesheet", "Image", "Font"}
+
+// CachedResponse recorded response served to the request with the same method and url
+type CachedResponse struct {
+	URL     string               `json:"url"`
+	Method  string               `json:"method"`
+	Status  int                  `json:"status"`
+	Headers []*fetch.HeaderEntry `json:"headers"`
+	Body    []byte               `json:"body"`
+}
+
+// ResponseCache responses recorded during warm-up run, it's safe to share between sessions
+type ResponseCache struct {
+	mx      sync.RWMutex
+	entries map[string]*CachedResponse
+}
+
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{entries: map[string]*CachedResponse{}}
+}
+
+// LoadResponseCache read response cache saved by ResponseCache.Save
+func LoadResponseCache(path string) (*ResponseCache, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list []*CachedResponse
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	var c = NewResponseCache()
+	for _, r := range list {
+		c.Put(r)
+	}
+	return c, nil
+}
+
+func responseCacheKey(method, url string) string {
+	return method + " " + url
+}
+
+// Save write responses to the file as JSON
+func (c *ResponseCache) Save(path string) error {
+	c.mx.RLock()
+	var list = make([]*CachedResponse, 0, len(c.entries))
+	for _, r := range c.entries {
+		list = append(list, r)
+	}
+	c.mx.RUnlock()
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Put add or replace response of the fixture set
+func (c *ResponseCache) Put(r *CachedResponse) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.entries[responseCacheKey(r.Method, r.URL)] = r
+}
+
+// Get returns recorded response or nil
+func (c *ResponseCache) Get(method, url string) *CachedResponse {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return c.entries[responseCacheKey(method, url)]
+}
+
+// Len number of recorded responses
+func (c *ResponseCache) Len() int {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return len(c.entries)
+}
+
+// interceptTypes registers handler for each resource type (StaticResourceTypes if empty)
+func (s Session) interceptTypes(stage fetch.RequestStage, types []network.ResourceType, handler RouteHandler) (cancel func(), err error) {
+	if len(types) == 0 {
+		types = StaticResourceTypes
+	}
+	var cancels []func()
+	cancel = func() {
+		for _, c := range cancels {
+			c()
+		}
+	}
+	for _, t := range types {
+		c, err := s.Intercept(fetch.RequestPattern{ResourceType: t, RequestStage: stage}, handler)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		cancels = append(cancels, c)
+	}
+	return cancel, nil
+}
+
+// RecordResponses record successful GET responses of given resource types (StaticResourceTypes if empty) into cache
+func (s Session) RecordResponses(cache *ResponseCache, types ...network.ResourceType) (cancel func(), err error) {
+	return s.interceptTypes(StageResponse, types, func(route *Route) {
+		if route.Request.Method != http.MethodGet || route.ResponseStatusCode != http.StatusOK {
+			return
+		}
+		body, err := route.ResponseBody()
+		if err != nil {
+			return
+		}
+		cache.Put(&CachedResponse{
+			URL:     route.Request.Url,
+			Method:  route.Request.Method,
+			Status:  route.ResponseStatusCode,
+			Headers: decodedBodyHeaders(route.ResponseHeaders),
+			Body:    body,
+		})
+	})
+}
+
+// ReplayResponses serve requests of given resource types (StaticResourceTypes if empty) from cache,
+// requests missing in cache are sent to the network
+func (s Session) ReplayResponses(cache *ResponseCache, types ...network.ResourceType) (cancel func(), err error) {
+	return s.interceptTypes(StageRequest, types, func(route *Route) {
+		if r := cache.Get(route.Request.Method, route.Request.Url); r != nil {
+			_ = route.FulfillWith(fetch.FulfillRequestArgs{
+				ResponseCode:    r.Status,
+				ResponseHeaders: r.Headers,
+				Body:            r.Body,
+			})
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package control
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+// Request request sent by the page
+type Request struct {
+	RequestID    network.RequestId
+	URL          string
+	Method       string
+	Headers      map[string]string // names are lower-cased
+	PostData     string            // may be omitted if it's too long, see Request.GetPostData
+	HasPostData  bool
+	ResourceType network.ResourceType
+	FrameID      common.FrameId
+	Timestamp    network.MonotonicTime
+	Raw          *network.RequestWillBeSent
+	session      *Session
+}
+
+func newRequest(s *Session, v *network.RequestWillBeSent) *Request {
+	return &Request{
+		RequestID:    v.RequestId,
+		URL:          v.Request.Url + v.Request.UrlFragment,
+		Method:       v.Request.Method,
+		Headers:      headerMap(v.Request.Headers),
+		PostData:     v.Request.PostData,
+		HasPostData:  v.Request.HasPostData,
+		ResourceType: v.Type,
+		FrameID:      v.FrameId,
+		Timestamp:    v.Timestamp,
+		Raw:          v,
+		session:      s,
+	}
+}
+
+// GetPostData returns post data of the request, it's requested from the browser if it was omitted in the event
+func (r Request) GetPostData() (string, error) {
+	if r.PostData != "" || !r.HasPostData {
+		return r.PostData, nil
+	}
+	return r.session.Network.GetRequestPostData(r.RequestID)
+}
+
+// JSON decode JSON post data of the request into out
+func (r Request) JSON(out interface{}) error {
+	data, err := r.GetPostData()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), out)
+}
+
+// RequestMatcher predicate of WaitForRequest
+type RequestMatcher func(*Request) bool
+
+// ResponseMatcher predicate of WaitForResponse
+type ResponseMatcher func(*Response) bool
+
+// MatchRequestURL matches requests by wildcard url pattern where '*' is zero or more and '?' is exactly one character,
+// method is optional
+func MatchRequestURL(pattern string, method string) RequestMatcher {
+	var url = compileURLPattern(pattern)
+	return func(r *Request) bool {
+		return (method == "" || strings.EqualFold(r.Method, method)) && url.MatchString(r.URL)
+	}
+}
+
+// MatchResponseURL matches responses by wildcard url pattern, status is optional
+func MatchResponseURL(pattern string, status int) ResponseMatcher {
+	var url = compileURLPattern(pattern)
+	return func(r *Response) bool {
+		return (status == 0 || r.Status == status) && url.MatchString(r.URL)
+	}
+}
+
+// OnRequest subscribe to requests sent by the page
+func (n Network) OnRequest(handler func(*Request)) (cancel func()) {
+	return n.s.Subscribe("Network.requestWillBeSent", func(e transport.Event) {
+		var v = network.RequestWillBeSent{}
+		if err := json.Unmarshal(e.Params, &v); err == nil && v.Request != nil {
+			handler(newRequest(n.s, &v))
+		}
+	})
+}
+
+// ExpectRequest returns future of the first request sent after the call and matched by matcher
+func (n Network) ExpectRequest(matcher RequestMatcher) Future {
+	return n.s.Observe("Network.requestWillBeSent", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		var v = network.RequestWillBeSent{}
+		if err := json.Unmarshal(value.Params, &v); err != nil {
+			reject(err)
+			return
+		}
+		if v.Request == nil {
+			return
+		}
+		if r := newRequest(n.s, &v); matcher(r) {
+			resolve(r)
+		}
+	})
+}
+
+// ExpectResponse returns future of the first response received after the call and matched by matcher
+func (n Network) ExpectResponse(matcher ResponseMatcher) Future {
+	var (
+		mx       sync.Mutex
+		requests = map[network.RequestId]*Request{}
+	)
+	return n.s.Observe("*", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		switch value.Method {
+		case "Network.requestWillBeSent":
+			var v = network.RequestWillBeSent{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.Request != nil {
+				mx.Lock()
+				requests[v.RequestId] = newRequest(n.s, &v)
+				mx.Unlock()
+			}
+		case "Network.responseReceived":
+			var v = network.ResponseReceived{}
+			if err := json.Unmarshal(value.Params, &v); err != nil {
+				reject(err)
+				return
+			}
+			if v.Response == nil {
+				return
+			}
+			var r = newResponse(n.s, v.RequestId, v.Response)
+			mx.Lock()
+			r.Request = requests[v.RequestId]
+			delete(requests, v.RequestId)
+			mx.Unlock()
+			if matcher(r) {
+				resolve(r)
+			}
+		case "Network.loadingFinished", "Network.loadingFailed":
+			var v = struct {
+				RequestId network.RequestId `json:"requestId"`
+			}{}
+			if err := json.Unmarshal(value.Params, &v); err == nil {
+				mx.Lock()
+				delete(requests, v.RequestId)
+				mx.Unlock()
+			}
+		}
+	})
+}
+
+// WaitForRequest waits for the request sent after the call and matched by matcher. To await request fired
+// by an action use ExpectRequest before the action, e.g. MatchRequestURL("*/api/cart", "POST")
+func (n Network) WaitForRequest(matcher RequestMatcher, timeout time.Duration) (*Request, error) {
+	future := n.ExpectRequest(matcher)
+	defer future.Cancel()
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return val.(*Request), nil
+}
+
+// WaitForResponse waits for the response received after the call and matched by matcher
+func (n Network) WaitForResponse(matcher ResponseMatcher, timeout time.Duration) (*Response, error) {
+	future := n.ExpectResponse(matcher)
+	defer future.Cancel()
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return val.(*Response), nil
+}
@@ -0,0 +1,243 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+// RequestTiming timing breakdown of the request, phases that didn't happen (e.g. DNS of reused connection) are 0
+type RequestTiming struct {
+	Queued   time.Duration // from request is issued until it's started by the network stack
+	DNS      time.Duration
+	Connect  time.Duration // TCP and TLS handshake
+	SSL      time.Duration // TLS handshake
+	Send     time.Duration
+	TTFB     time.Duration // time to first byte: from request is sent until response headers are received
+	Download time.Duration // from response headers are received until loading is finished
+	Total    time.Duration // from request is issued until loading is finished
+}
+
+// RequestMetrics timing and sizes of finished request
+type RequestMetrics struct {
+	RequestID        network.RequestId
+	URL              string
+	Method           string
+	Status           int
+	ResourceType     network.ResourceType
+	Protocol         string // e.g. "http/1.1", "h2", "h3"
+	RemoteAddress    string
+	ConnectionReused bool
+	FromCache        bool
+	Timing           RequestTiming
+	TransferSize     int    // bytes transferred over the network including headers
+	DecodedBodySize  int    // bytes of decoded body
+	Failed           string // error text if loading failed
+}
+
+// requestMetrics metrics of the request being loaded with monotonic timestamps (seconds)
+type requestMetrics struct {
+	*RequestMetrics
+	issued float64
+	timing *network.ResourceTiming
+}
+
+func msDuration(ms float64) time.Duration {
+	if ms <= 0 {
+		return 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// requestPhases timing breakdown (ms) of the request issued and finished at monotonic timestamps (seconds),
+// it's shared by HAR and request metrics. DNS, Connect and SSL that didn't happen are -1, other phases are
+// at least 0. Connect includes SSL as HAR requires
+func requestPhases(issued, finished float64, tm *network.ResourceTiming) HARTimings {
+	var t = HARTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1}
+	if tm == nil {
+		t.Receive = (finished - issued) * 1000
+	} else {
+		var first = func(values ...float64) float64 {
+			for _, v := range values {
+				if v >= 0 {
+					return v
+				}
+			}
+			return 0
+		}
+		t.Blocked = (tm.RequestTime-issued)*1000 + first(tm.DnsStart, tm.ConnectStart, tm.SendStart)
+		if tm.DnsStart >= 0 {
+			t.DNS = tm.DnsEnd - tm.DnsStart
+		}
+		if tm.ConnectStart >= 0 {
+			t.Connect = tm.ConnectEnd - tm.ConnectStart
+		}
+		if tm.SslStart >= 0 {
+			t.SSL = tm.SslEnd - tm.SslStart
+		}
+		t.Send = tm.SendEnd - tm.SendStart
+		t.Wait = tm.ReceiveHeadersEnd - tm.SendEnd
+		t.Receive = (finished-tm.RequestTime)*1000 - tm.ReceiveHeadersEnd
+	}
+	for _, v := range []*float64{&t.Blocked, &t.Send, &t.Wait, &t.Receive} {
+		if *v < 0 {
+			*v = 0
+		}
+	}
+	return t
+}
+
+// total time of the request (ms), SSL is a part of Connect
+func (t HARTimings) total() float64 {
+	var total = t.Blocked + t.Send + t.Wait + t.Receive
+	for _, v := range []float64{t.DNS, t.Connect} {
+		if v > 0 {
+			total += v
+		}
+	}
+	return total
+}
+
+// finish compute timing breakdown of the request finished at monotonic timestamp
+func (m *requestMetrics) finish(timestamp float64) {
+	var t = requestPhases(m.issued, timestamp, m.timing)
+	m.Timing = RequestTiming{
+		Queued:   msDuration(t.Blocked),
+		DNS:      msDuration(t.DNS),
+		Connect:  msDuration(t.Connect),
+		SSL:      msDuration(t.SSL),
+		Send:     msDuration(t.Send),
+		TTFB:     msDuration(t.Wait),
+		Download: msDuration(t.Receive),
+		Total:    msDuration(t.total()),
+	}
+}
+
+// response fill metrics from the response of the request (or of its redirect)
+func (m *requestMetrics) response(v *network.Response) {
+	m.Status = v.Status
+	m.Protocol = v.Protocol
+	m.RemoteAddress = v.RemoteIPAddress
+	m.ConnectionReused = v.ConnectionReused
+	m.FromCache = v.FromDiskCache || v.FromPrefetchCache
+	m.timing = v.Timing
+}
+
+// OnRequestMetrics subscribe to metrics of requests finished (or failed) after the call. Every hop of
+// redirected request is reported, hops share RequestID and the hop is finished when the next one is issued
+func (n Network) OnRequestMetrics(handler func(*RequestMetrics)) (cancel func()) {
+	var (
+		mx       sync.Mutex
+		inflight = map[network.RequestId]*requestMetrics{}
+	)
+	return n.s.Subscribe("*", func(e transport.Event) {
+		switch e.Method {
+		case "Network.requestWillBeSent":
+			var v = network.RequestWillBeSent{}
+			if err := json.Unmarshal(e.Params, &v); err != nil || v.Request == nil {
+				return
+			}
+			mx.Lock()
+			// redirected request is reported with the same id, the previous hop is finished by the redirect
+			var hop, redirected = inflight[v.RequestId]
+			inflight[v.RequestId] = &requestMetrics{
+				RequestMetrics: &RequestMetrics{
+					RequestID:    v.RequestId,
+					URL:          v.Request.Url,
+					Method:       v.Request.Method,
+					ResourceType: v.Type,
+				},
+				issued: float64(v.Timestamp),
+			}
+			mx.Unlock()
+			if redirected && v.RedirectResponse != nil {
+				hop.response(v.RedirectResponse)
+				hop.finish(float64(v.Timestamp))
+				handler(hop.RequestMetrics)
+			}
+
+		case "Network.responseReceived":
+			var v = network.ResponseReceived{}
+			if err := json.Unmarshal(e.Params, &v); err != nil || v.Response == nil {
+				return
+			}
+			mx.Lock()
+			if m, ok := inflight[v.RequestId]; ok {
+				m.response(v.Response)
+			}
+			mx.Unlock()
+
+		case "Network.dataReceived":
+			var v = network.DataReceived{}
+			if err := json.Unmarshal(e.Params, &v); err != nil {
+				return
+			}
+			mx.Lock()
+			if m, ok := inflight[v.RequestId]; ok {
+				m.DecodedBodySize += v.DataLength
+			}
+			mx.Unlock()
+
+		case "Network.loadingFinished":
+			var v = network.LoadingFinished{}
+			if err := json.Unmarshal(e.Params, &v); err != nil {
+				return
+			}
+			mx.Lock()
+			m, ok := inflight[v.RequestId]
+			delete(inflight, v.RequestId)
+			mx.Unlock()
+			if ok {
+				m.TransferSize = int(v.EncodedDataLength)
+				m.finish(float64(v.Timestamp))
+				handler(m.RequestMetrics)
+			}
+
+		case "Network.loadingFailed":
+			var v = network.LoadingFailed{}
+			if err := json.Unmarshal(e.Params, &v); err != nil {
+				return
+			}
+			mx.Lock()
+			m, ok := inflight[v.RequestId]
+			delete(inflight, v.RequestId)
+			mx.Unlock()
+			if ok {
+				m.Failed = v.ErrorText
+				m.finish(float64(v.Timestamp))
+				handler(m.RequestMetrics)
+			}
+		}
+	})
+}
+
+// WaitForRequestMetrics waits for the request which url matches wildcard pattern to finish after the call
+// and returns its metrics, e.g. to check budget of the endpoint: m.Timing.TTFB < 200*time.Millisecond
+func (n Network) WaitForRequestMetrics(urlPattern string, timeout time.Duration) (*RequestMetrics, error) {
+	var (
+		url    = compileURLPattern(urlPattern)
+		result = make(chan *RequestMetrics, 1)
+	)
+	cancel := n.OnRequestMetrics(func(m *RequestMetrics) {
+		if url.MatchString(m.URL) {
+			select {
+			case result <- m:
+			default:
+			}
+		}
+	})
+	defer cancel()
+	var deadline = n.s.Clock().NewTimer(timeout)
+	defer deadline.Stop()
+	select {
+	case m := <-result:
+		return m, nil
+	case <-deadline.C():
+		return nil, FutureTimeoutError{timeout: timeout}
+	case <-n.s.context.Done():
+		return nil, n.s.context.Err()
+	}
+}
@@ -0,0 +1,139 @@
+package control
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ecwid/control/protocol"
+	"github.com/ecwid/control/protocol/fetch"
+	protocolio "github.com/ecwid/control/protocol/io"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+// Response response received by the page
+type Response struct {
+	RequestID         network.RequestId
+	URL               string
+	Status            int
+	StatusText        string
+	MimeType          string
+	Headers           map[string]string // names are lower-cased
+	FromCache         bool
+	FromServiceWorker bool
+	Source            CacheSource
+	Timing            *network.ResourceTiming
+	Raw               *network.Response
+	Request           *Request // request of the response if it was sent while response was awaited
+	session           *Session
+}
+
+// headerMap network headers with lower-cased names, repeated headers are joined by "\n"
+func headerMap(h *network.Headers) map[string]string {
+	var headers = map[string]string{}
+	if h == nil {
+		return headers
+	}
+	if m, ok := (*h).(map[string]interface{}); ok {
+		for name, value := range m {
+			headers[strings.ToLower(name)] = fmt.Sprint(value)
+		}
+	}
+	return headers
+}
+
+func newResponse(s *Session, id network.RequestId, r *network.Response) *Response {
+	return &Response{
+		RequestID:         id,
+		URL:               r.Url,
+		Status:            r.Status,
+		StatusText:        r.StatusText,
+		MimeType:          r.MimeType,
+		Headers:           headerMap(r.Headers),
+		FromCache:         r.FromDiskCache || r.FromPrefetchCache || s.servedFromMemoryCache(id),
+		FromServiceWorker: r.FromServiceWorker,
+		Source:            s.cacheSource(id, r),
+		Timing:            r.Timing,
+		Raw:               r,
+		session:           s,
+	}
+}
+
+// Body returns body of the response, it's available when loading is finished and until the page evicts it from the buffer
+func (r Response) Body() ([]byte, error) {
+	val, err := network.GetResponseBody(r.session, network.GetResponseBodyArgs{RequestId: r.RequestID})
+	if err != nil {
+		return nil, err
+	}
+	if val.Base64Encoded {
+		return base64.StdEncoding.DecodeString(val.Body)
+	}
+	return []byte(val.Body), nil
+}
+
+// JSON decode JSON body of the response into out
+func (r Response) JSON(out interface{}) error {
+	body, err := r.Body()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// OnResponse subscribe to responses received by the page
+func (n Network) OnResponse(handler func(*Response)) (cancel func()) {
+	return n.s.Subscribe("Network.responseReceived", func(e transport.Event) {
+		var v = network.ResponseReceived{}
+		if err := json.Unmarshal(e.Params, &v); err == nil && v.Response != nil {
+			handler(newResponse(n.s, v.RequestId, v.Response))
+		}
+	})
+}
+
+// stream reader of protocol IO stream
+type stream struct {
+	caller protocol.Caller
+	handle protocolio.StreamHandle
+	buf    []byte
+	eof    bool
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.eof {
+			return 0, io.EOF
+		}
+		val, err := protocolio.Read(s.caller, protocolio.ReadArgs{Handle: s.handle, Size: len(p)})
+		if err != nil {
+			return 0, err
+		}
+		s.eof = val.Eof
+		if val.Base64Encoded {
+			if s.buf, err = base64.StdEncoding.DecodeString(val.Data); err != nil {
+				return 0, err
+			}
+		} else {
+			s.buf = []byte(val.Data)
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *stream) Close() error {
+	return protocolio.Close(s.caller, protocolio.CloseArgs{Handle: s.handle})
+}
+
+// ResponseBodyStream returns body of the response as stream for large bodies, available at the response stage only.
+// After that the route can't be continued as is, it should be fulfilled or failed
+func (r Route) ResponseBodyStream() (io.ReadCloser, error) {
+	val, err := fetch.TakeResponseBodyAsStream(r.session, fetch.TakeResponseBodyAsStreamArgs{RequestId: r.RequestId})
+	if err != nil {
+		return nil, err
+	}
+	return &stream{caller: r.session, handle: val.Stream}, nil
+}
@@ -0,0 +1,64 @@
+package control
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ecwid/control/protocol/fetch"
+)
+
+// ResponseTransform transforms real body of the response, status and headers of the route can be inspected
+type ResponseTransform func(route *Route, body []byte) ([]byte, error)
+
+// decodedBodyHeaders response headers without content encoding and length, Route.ResponseBody is already decoded
+// and fulfilled body may have other length
+func decodedBodyHeaders(headers []*fetch.HeaderEntry) []*fetch.HeaderEntry {
+	var list []*fetch.HeaderEntry
+	for _, h := range headers {
+		switch http.CanonicalHeaderKey(h.Name) {
+		case "Content-Encoding", "Content-Length":
+		default:
+			list = append(list, h)
+		}
+	}
+	return list
+}
+
+// RewriteResponse intercept responses matching pattern, pass real body to transform and fulfill requests
+// with transformed body keeping status and headers. If transform fails then the original response is continued
+func (s Session) RewriteResponse(pattern fetch.RequestPattern, transform ResponseTransform) (cancel func(), err error) {
+	pattern.RequestStage = StageResponse
+	return s.Intercept(pattern, func(route *Route) {
+		if route.ResponseErrorReason != "" || isRedirect(route.ResponseStatusCode) {
+			return
+		}
+		body, err := route.ResponseBody()
+		if err != nil {
+			return
+		}
+		if body, err = transform(route, body); err != nil {
+			return
+		}
+		_ = route.FulfillWith(fetch.FulfillRequestArgs{
+			ResponseCode:    route.ResponseStatusCode,
+			ResponseHeaders: decodedBodyHeaders(route.ResponseHeaders),
+			Body:            body,
+		})
+	})
+}
+
+// RewriteJSON rewrite JSON responses matching pattern, modify receives decoded body
+// (map[string]interface{}, []interface{}, ...) and returns value to encode as new body
+func (s Session) RewriteJSON(pattern fetch.RequestPattern, modify func(value interface{}) interface{}) (cancel func(), err error) {
+	return s.RewriteResponse(pattern, func(route *Route, body []byte) ([]byte, error) {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return nil, err
+		}
+		return json.Marshal(modify(value))
+	})
+}
+
+func isRedirect(code int) bool {
+	return code >= 300 && code < 400 && code != http.StatusNotModified
+}
@@ -1,6 +1,8 @@
 package control
 
 import (
+	"encoding/json"
+
 	"github.com/ecwid/control/protocol/runtime"
 )
 
@@ -43,3 +45,74 @@ func (f Frame) getProperties(objectID runtime.RemoteObjectId, ownProperties, acc
 	}
 	return val.Result, nil
 }
+
+// unmarshal decode value of RemoteObject (returned by value) into out
+func (p primitiveRemoteObject) unmarshal(out interface{}) error {
+	b, err := json.Marshal(p.Value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// Eval evaluate expression in main frame and decode result into out
+func (s Session) Eval(expression string, out interface{}) error {
+	return s.Page().Eval(expression, out)
+}
+
+// Eval evaluate expression and decode result into out
+func (f Frame) Eval(expression string, out interface{}) error {
+	val, err := f.evaluate(expression, true, true)
+	if err != nil {
+		return err
+	}
+	return primitiveRemoteObject(*val).unmarshal(out)
+}
+
+// newCallArguments values of arguments, JSHandle arguments are passed by reference
+func newCallArguments(args ...interface{}) []*runtime.CallArgument {
+	var arguments = make([]*runtime.CallArgument, len(args))
+	for i, a := range args {
+		switch v := a.(type) {
+		case *JSHandle:
+			arguments[i] = v.CallArgument()
+		case JSHandle:
+			arguments[i] = v.CallArgument()
+		default:
+			arguments[i] = &runtime.CallArgument{Value: a}
+		}
+	}
+	return arguments
+}
+
+// CallInto call function on the element and decode result into out
+func (e Element) CallInto(function string, out interface{}, args ...interface{}) error {
+	val, err := e.CallFunction(function, true, true, newCallArguments(args...))
+	if err != nil {
+		return err
+	}
+	return primitiveRemoteObject(*val).unmarshal(out)
+}
+
+// Evaluate evaluate expression in main frame, if await is true then promise result is awaited
+func (s Session) Evaluate(expression string, await, returnByValue bool) (interface{}, error) {
+	return s.Page().Evaluate(expression, await, returnByValue)
+}
+
+// EvalAsync call async function in main frame and decode its resolved value into out
+func (s Session) EvalAsync(function string, out interface{}, args ...interface{}) error {
+	return s.Page().EvalAsync(function, out, args...)
+}
+
+// EvalAsync call async function (e.g. fetch-based helper) with args and decode its resolved value into out
+func (f Frame) EvalAsync(function string, out interface{}, args ...interface{}) error {
+	var cid, ok = f.session.executions.Load(f.id)
+	if !ok {
+		return ErrExecutionContextDestroyed
+	}
+	val, err := f.session.callFunctionIn(cid.(runtime.ExecutionContextId), function, newCallArguments(args...))
+	if err != nil {
+		return err
+	}
+	return primitiveRemoteObject(*val).unmarshal(out)
+}
@@ -0,0 +1,65 @@
+package control
+
+import "sync"
+
+// ScrollOffset space at the top of viewport that ScrollIntoView keeps free (e.g. for fixed toolbars)
+type ScrollOffset struct {
+	Top  float64 // px
+	Auto bool    // detect position:fixed/sticky headers at the top of viewport
+}
+
+type scrollOffset struct {
+	mx      sync.Mutex
+	value   ScrollOffset
+	restore bool // restore scroll positions around read-only operations
+}
+
+func (s *scrollOffset) get() ScrollOffset {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.value
+}
+
+// SetScrollOffset set offset applied by Element.ScrollIntoView, so elements are not scrolled underneath sticky headers
+func (s Session) SetScrollOffset(offset ScrollOffset) {
+	s.scrollOffset.mx.Lock()
+	defer s.scrollOffset.mx.Unlock()
+	s.scrollOffset.value = offset
+}
+
+// SetScrollRestore toggles restoring of scroll positions of the window and scrollable containers after
+// read-only operations that must scroll the element into view (Element.GetRectangleInView, Element.CaptureScreenshot)
+func (s Session) SetScrollRestore(restore bool) {
+	s.scrollOffset.mx.Lock()
+	defer s.scrollOffset.mx.Unlock()
+	s.scrollOffset.restore = restore
+}
+
+func (s *scrollOffset) restoring() bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.restore
+}
+
+// PreserveScroll records scroll positions of the window and scrollable ancestors of the element,
+// performs action and restores them regardless of action result
+func (e Element) PreserveScroll(action func() error) error {
+	saved, err := e.Handle().Call(functionSaveScroll)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = saved.Dispose() }()
+	err = action()
+	if _, restoreErr := saved.callFunction(functionRestoreScroll, true); err == nil {
+		err = restoreErr
+	}
+	return err
+}
+
+// inspect performs read-only action preserving scroll positions if it's enabled by SetScrollRestore
+func (e Element) inspect(action func() error) error {
+	if !e.frame.session.scrollOffset.restoring() {
+		return action()
+	}
+	return e.PreserveScroll(action)
+}
@@ -0,0 +1,143 @@
+package control
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/security"
+	"github.com/ecwid/control/transport"
+)
+
+// SecurityFindingKind kind of security problem found during the run
+type SecurityFindingKind string
+
+const (
+	SecurityMixedContent       SecurityFindingKind = "mixedContent"
+	SecurityInvalidCertificate SecurityFindingKind = "invalidCertificate"
+	SecurityDeprecatedTLS      SecurityFindingKind = "deprecatedTLS"
+	SecurityInsecurePage       SecurityFindingKind = "insecurePage" // security state of the page is broken
+)
+
+const (
+	mixedContentNone            security.MixedContentType = "none"
+	securityStateInsecureBroken security.SecurityState    = "insecure-broken"
+)
+
+// deprecatedTLS protocols reported by securityDetails
+var deprecatedTLS = map[string]bool{"SSL 3.0": true, "TLS 1.0": true, "TLS 1.1": true}
+
+// SecurityFinding security problem of the page or its resource
+type SecurityFinding struct {
+	Kind   SecurityFindingKind
+	URL    string // url of the resource, empty for findings of the page
+	Detail string
+}
+
+// SecurityWatcher collects security findings of the session, see Session.WatchSecurity
+type SecurityWatcher struct {
+	mx       sync.Mutex
+	findings []SecurityFinding
+	seen     map[SecurityFinding]bool
+	cancel   func()
+}
+
+// WatchSecurity enable Security domain and start collecting of mixed content, invalid certificates
+// and deprecated TLS versions from security state of the page and responses
+func (s Session) WatchSecurity() (*SecurityWatcher, error) {
+	var w = &SecurityWatcher{seen: map[SecurityFinding]bool{}}
+	w.cancel = s.Subscribe("*", w.observe)
+	if err := security.Enable(s); err != nil {
+		w.cancel()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *SecurityWatcher) add(kind SecurityFindingKind, url, detail string) {
+	var f = SecurityFinding{Kind: kind, URL: url, Detail: detail}
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	if !w.seen[f] {
+		w.seen[f] = true
+		w.findings = append(w.findings, f)
+	}
+}
+
+func (w *SecurityWatcher) observe(e transport.Event) {
+	switch e.Method {
+	case "Network.requestWillBeSent":
+		var v = network.RequestWillBeSent{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Request == nil {
+			return
+		}
+		if t := v.Request.MixedContentType; t != "" && t != mixedContentNone {
+			w.add(SecurityMixedContent, v.Request.Url, string(t))
+		}
+
+	case "Network.responseReceived":
+		var v = network.ResponseReceived{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Response == nil {
+			return
+		}
+		if d := v.Response.SecurityDetails; d != nil && deprecatedTLS[d.Protocol] {
+			w.add(SecurityDeprecatedTLS, v.Response.Url, d.Protocol)
+		}
+
+	case "Network.loadingFailed":
+		var v = network.LoadingFailed{}
+		if err := json.Unmarshal(e.Params, &v); err == nil && strings.HasPrefix(v.ErrorText, "net::ERR_CERT_") {
+			w.add(SecurityInvalidCertificate, "", v.ErrorText)
+		}
+
+	case "Security.visibleSecurityStateChanged":
+		var v = security.VisibleSecurityStateChanged{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.VisibleSecurityState == nil {
+			return
+		}
+		var state = v.VisibleSecurityState
+		if state.SecurityState == securityStateInsecureBroken {
+			w.add(SecurityInsecurePage, "", strings.Join(state.SecurityStateIssueIds, ","))
+		}
+		if c := state.CertificateSecurityState; c != nil {
+			if c.CertificateNetworkError != "" {
+				w.add(SecurityInvalidCertificate, "", c.CertificateNetworkError)
+			}
+			if c.ObsoleteSslProtocol {
+				w.add(SecurityDeprecatedTLS, "", c.Protocol)
+			}
+		}
+
+	case "Security.securityStateChanged":
+		var v = security.SecurityStateChanged{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		for _, x := range v.Explanations {
+			if x.MixedContentType != "" && x.MixedContentType != mixedContentNone {
+				w.add(SecurityMixedContent, "", x.Title)
+			}
+		}
+	}
+}
+
+// Findings returns findings collected so far in order of discovery, repeated findings are reported once
+func (w *SecurityWatcher) Findings() []SecurityFinding {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	return append([]SecurityFinding{}, w.findings...)
+}
+
+// Clear forget collected findings
+func (w *SecurityWatcher) Clear() {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	w.findings = nil
+	w.seen = map[SecurityFinding]bool{}
+}
+
+// Stop stop collecting, findings are still available
+func (w *SecurityWatcher) Stop() {
+	w.cancel()
+}
@@ -0,0 +1,115 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// SeedFunc creates test data (e.g. by HTTP/gRPC call to the backend) and returns ids of created entities by name.
+// values are the values produced by previous seeds of the session
+type SeedFunc func(ctx context.Context, values map[string]string) (map[string]string, error)
+
+var seedFuncs = struct {
+	mx    sync.RWMutex
+	funcs map[string]SeedFunc
+}{funcs: map[string]SeedFunc{}}
+
+// RegisterSeed register named seeding function, it's available to every session by the name
+func RegisterSeed(name string, fn SeedFunc) {
+	seedFuncs.mx.Lock()
+	defer seedFuncs.mx.Unlock()
+	seedFuncs.funcs[name] = fn
+}
+
+type seeds struct {
+	mx      sync.Mutex
+	values  map[string]string
+	pending []string // seeds to run before the next navigation
+}
+
+func newSeeds() *seeds {
+	return &seeds{values: map[string]string{}}
+}
+
+// Seed run registered seeding functions in order, every value is stored as `<seed name>.<key>`
+func (s Session) Seed(names ...string) (map[string]string, error) {
+	if err := s.seeds.run(s.context, names); err != nil {
+		return nil, err
+	}
+	return s.SeedValues(), nil
+}
+
+// run seeding functions without the lock, so they may use the session (e.g. Expand), values are merged under it
+func (d *seeds) run(ctx context.Context, names []string) error {
+	for _, name := range names {
+		seedFuncs.mx.RLock()
+		fn, ok := seedFuncs.funcs[name]
+		seedFuncs.mx.RUnlock()
+		if !ok {
+			return fmt.Errorf("seed `%s` is not registered", name)
+		}
+		d.mx.Lock()
+		var values = d.copy()
+		d.mx.Unlock()
+		val, err := fn(ctx, values)
+		if err != nil {
+			return fmt.Errorf("seed `%s` failed: %w", name, err)
+		}
+		d.mx.Lock()
+		for key, value := range val {
+			d.values[name+"."+key] = value
+		}
+		d.mx.Unlock()
+	}
+	return nil
+}
+
+func (d *seeds) copy() map[string]string {
+	var values = make(map[string]string, len(d.values))
+	for key, value := range d.values {
+		values[key] = value
+	}
+	return values
+}
+
+// SeedBeforeNavigation defer seeding until the next Navigate of any frame of the page
+func (s Session) SeedBeforeNavigation(names ...string) {
+	s.seeds.mx.Lock()
+	defer s.seeds.mx.Unlock()
+	s.seeds.pending = append(s.seeds.pending, names...)
+}
+
+func (s Session) runPendingSeeds() error {
+	s.seeds.mx.Lock()
+	var names = s.seeds.pending
+	s.seeds.pending = nil
+	s.seeds.mx.Unlock()
+	if len(names) == 0 {
+		return nil
+	}
+	return s.seeds.run(s.context, names)
+}
+
+// SeedValues returns all the values produced by seeds of the session
+func (s Session) SeedValues() map[string]string {
+	s.seeds.mx.Lock()
+	defer s.seeds.mx.Unlock()
+	return s.seeds.copy()
+}
+
+var seedPlaceholder = regexp.MustCompile(`{{\s*([\w.-]+)\s*}}`)
+
+// Expand replaces `{{seed.key}}` placeholders of the template with seeded values, unknown placeholders
+// are left as is. Navigate expands url, selectors and text of other calls are taken literally
+func (s Session) Expand(template string) string {
+	s.seeds.mx.Lock()
+	defer s.seeds.mx.Unlock()
+	return seedPlaceholder.ReplaceAllStringFunc(template, func(m string) string {
+		if value, ok := s.seeds.values[seedPlaceholder.FindStringSubmatch(m)[1]]; ok {
+			return value
+		}
+		return m
+	})
+}
@@ -0,0 +1,27 @@
+package control
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSeedUsesSession(t *testing.T) {
+	s, _, _ := testSession(t)
+	RegisterSeed("test.user", func(context.Context, map[string]string) (map[string]string, error) {
+		return map[string]string{"id": "42"}, nil
+	})
+	RegisterSeed("test.order", func(_ context.Context, values map[string]string) (map[string]string, error) {
+		// seeding function may use the session, e.g. expand values of previous seeds
+		return map[string]string{"url": s.Expand("/users/{{test.user.id}}/orders")}, nil
+	})
+	values, err := s.Seed("test.user", "test.order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url := values["test.order.url"]; url != "/users/42/orders" {
+		t.Fatalf("unexpected value %s", url)
+	}
+	if v := s.Expand("{{test.order.url}}?{{unknown}}"); v != "/users/42/orders?{{unknown}}" {
+		t.Fatalf("unexpected expansion %s", v)
+	}
+}
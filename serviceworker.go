@@ -0,0 +1,180 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/serviceworker"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	ServiceWorkerNew        serviceworker.ServiceWorkerVersionStatus = "new"
+	ServiceWorkerInstalling serviceworker.ServiceWorkerVersionStatus = "installing"
+	ServiceWorkerInstalled  serviceworker.ServiceWorkerVersionStatus = "installed"
+	ServiceWorkerActivating serviceworker.ServiceWorkerVersionStatus = "activating"
+	ServiceWorkerActivated  serviceworker.ServiceWorkerVersionStatus = "activated"
+	ServiceWorkerRedundant  serviceworker.ServiceWorkerVersionStatus = "redundant"
+)
+
+// ServiceWorkers service workers control of the page origin, registrations and versions are tracked after Enable
+type ServiceWorkers struct {
+	s *Session
+}
+
+type serviceWorkers struct {
+	mx            sync.Mutex
+	registrations map[serviceworker.RegistrationID]*serviceworker.ServiceWorkerRegistration
+	versions      map[string]*serviceworker.ServiceWorkerVersion
+}
+
+func newServiceWorkers() *serviceWorkers {
+	return &serviceWorkers{
+		registrations: map[serviceworker.RegistrationID]*serviceworker.ServiceWorkerRegistration{},
+		versions:      map[string]*serviceworker.ServiceWorkerVersion{},
+	}
+}
+
+func (s Session) observeServiceWorkers(e transport.Event) {
+	switch e.Method {
+	case "ServiceWorker.workerRegistrationUpdated":
+		var v = serviceworker.WorkerRegistrationUpdated{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		s.serviceWorkers.mx.Lock()
+		for _, r := range v.Registrations {
+			if r.IsDeleted {
+				delete(s.serviceWorkers.registrations, r.RegistrationId)
+			} else {
+				s.serviceWorkers.registrations[r.RegistrationId] = r
+			}
+		}
+		s.serviceWorkers.mx.Unlock()
+	case "ServiceWorker.workerVersionUpdated":
+		var v = serviceworker.WorkerVersionUpdated{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		s.serviceWorkers.mx.Lock()
+		for _, version := range v.Versions {
+			if version.Status == ServiceWorkerRedundant {
+				delete(s.serviceWorkers.versions, version.VersionId)
+			} else {
+				s.serviceWorkers.versions[version.VersionId] = version
+			}
+		}
+		s.serviceWorkers.mx.Unlock()
+	}
+}
+
+// Enable start tracking of registrations and versions
+func (w ServiceWorkers) Enable() error {
+	return serviceworker.Enable(w.s)
+}
+
+// Disable stop tracking of registrations and versions
+func (w ServiceWorkers) Disable() error {
+	return serviceworker.Disable(w.s)
+}
+
+// SetBypass toggles ignoring of service worker for each request of the page
+func (w ServiceWorkers) SetBypass(bypass bool) error {
+	return network.SetBypassServiceWorker(w.s, network.SetBypassServiceWorkerArgs{Bypass: bypass})
+}
+
+// Registrations returns known registrations
+func (w ServiceWorkers) Registrations() []*serviceworker.ServiceWorkerRegistration {
+	w.s.serviceWorkers.mx.Lock()
+	defer w.s.serviceWorkers.mx.Unlock()
+	var list = make([]*serviceworker.ServiceWorkerRegistration, 0, len(w.s.serviceWorkers.registrations))
+	for _, r := range w.s.serviceWorkers.registrations {
+		list = append(list, r)
+	}
+	return list
+}
+
+// Versions returns known not redundant versions
+func (w ServiceWorkers) Versions() []*serviceworker.ServiceWorkerVersion {
+	w.s.serviceWorkers.mx.Lock()
+	defer w.s.serviceWorkers.mx.Unlock()
+	var list = make([]*serviceworker.ServiceWorkerVersion, 0, len(w.s.serviceWorkers.versions))
+	for _, v := range w.s.serviceWorkers.versions {
+		list = append(list, v)
+	}
+	return list
+}
+
+// Unregister unregister service worker of the scope
+func (w ServiceWorkers) Unregister(scopeURL string) error {
+	return serviceworker.Unregister(w.s, serviceworker.UnregisterArgs{ScopeURL: scopeURL})
+}
+
+// UnregisterAll unregister all known registrations
+func (w ServiceWorkers) UnregisterAll() error {
+	for _, r := range w.Registrations() {
+		if err := w.Unregister(r.ScopeURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SkipWaiting activate waiting version of the scope
+func (w ServiceWorkers) SkipWaiting(scopeURL string) error {
+	return serviceworker.SkipWaiting(w.s, serviceworker.SkipWaitingArgs{ScopeURL: scopeURL})
+}
+
+// StopAll stop all running service workers
+func (w ServiceWorkers) StopAll() error {
+	return serviceworker.StopAllWorkers(w.s)
+}
+
+// OnVersionUpdated subscribe to version state changes (status and running status)
+func (w ServiceWorkers) OnVersionUpdated(handler func(*serviceworker.ServiceWorkerVersion)) (cancel func()) {
+	return w.s.Subscribe("ServiceWorker.workerVersionUpdated", func(e transport.Event) {
+		var v = serviceworker.WorkerVersionUpdated{}
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			for _, version := range v.Versions {
+				handler(version)
+			}
+		}
+	})
+}
+
+func (w ServiceWorkers) activated(scopeURL string) *serviceworker.ServiceWorkerVersion {
+	w.s.serviceWorkers.mx.Lock()
+	defer w.s.serviceWorkers.mx.Unlock()
+	for _, v := range w.s.serviceWorkers.versions {
+		if v.Status != ServiceWorkerActivated {
+			continue
+		}
+		if r, ok := w.s.serviceWorkers.registrations[v.RegistrationId]; scopeURL == "" || ok && r.ScopeURL == scopeURL {
+			return v
+		}
+	}
+	return nil
+}
+
+// WaitForActivation wait until version of the scope (any scope if empty) is activated, tracking must be enabled
+func (w ServiceWorkers) WaitForActivation(scopeURL string, timeout time.Duration) (*serviceworker.ServiceWorkerVersion, error) {
+	future := w.s.Observe("*", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		if value.Method != "ServiceWorker.workerVersionUpdated" && value.Method != "ServiceWorker.workerRegistrationUpdated" {
+			return
+		}
+		if v := w.activated(scopeURL); v != nil {
+			resolve(v)
+		}
+	})
+	defer future.Cancel()
+	if v := w.activated(scopeURL); v != nil {
+		return v, nil
+	}
+	val, err := future.Get(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return val.(*serviceworker.ServiceWorkerVersion), nil
+}
@@ -4,18 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/page"
 	"github.com/ecwid/control/protocol/runtime"
 	"github.com/ecwid/control/protocol/target"
 	"github.com/ecwid/control/transport"
 )
 
 const (
-	Blank     = "about:blank"
-	bindClick = "_on_click"
+	Blank      = "about:blank"
+	bindClick  = "_on_click"
+	bindExpose = "_on_expose"
+	// bindMutation reports records of MutationObserver installed by WaitForMutation
+	bindMutation = "_on_mutation"
 )
 
 type Session struct {
@@ -26,24 +34,88 @@ type Session struct {
 	eventPool  chan transport.Event
 	context    context.Context
 	exit       func()
-	exitCode   error
 	publisher  *transport.Publisher
 	guid       *uint64 // observers incremental id
-	Network    Network
-	Input      Input
-	Emulation  Emulation
+	stats      *sessionStats
+	scripts    *sync.Map // scripts to evaluate on new document
+	missing    *sync.Map // domains (failed to enable) and methods not supported by the target
+	worlds     *sync.Map // isolated worlds execution contexts by worldKey
+	// lifecycle state of the session
+	lifecycleState *stateMachine
+	loadStates     *loadStates
+	actions        *sync.Map // input actions mutex by frame id
+	oopifs         *sync.Map // sessions of out-of-process iframes by frame id
+	oopifHooks     *sync.Map // hooks called when out-of-process iframe is attached
+	children       *sync.Map // session ids of auto-attached targets
+	workers        *sync.Map // attached workers by target id
+	workerHooks    *sync.Map
+	decodeHooks    *sync.Map // hooks of events failed to decode by typed subscriptions
+	scrollOffset   *scrollOffset
+	ocr            *ocrHolder
+	dialogs        *dialogs
+	routes         *routes // request interception handlers
+	prerender      *prerenders
+	seeds          *seeds
+	serviceWorkers *serviceWorkers
+	soft           *softAssertions
+	pageErrors     *pageErrors
+	clock          *sessionClock
+	checkpoints    *checkpoints
+	animations     *animations
+	eventSources   *sync.Map // urls of EventSource streams by request id
+	extraHeaders   *extraHeaders
+	fromCache      *sync.Map // ids of requests served from memory cache
+	slowMo         *int64    // time.Duration of SetSlowMo, negative means slow-mo of browser context
+	closed         func()    // run statistics of the session lifetime
+	Network        Network
+	Input          Input
+	Emulation      Emulation
+	ServiceWorkers ServiceWorkers
+	Animations     Animations
 }
 
 func (s Session) Call(method string, send, recv interface{}) error {
-	select {
-	case <-s.context.Done():
-		if s.exitCode != nil {
-			return s.exitCode
+	if current := s.current(); current.id != s.id {
+		return current.Call(method, send, recv)
+	}
+	if state, cause := s.lifecycleState.get(); state.IsTerminal() {
+		return InvalidStateError{State: state, Method: method, Cause: cause}
+	}
+	if !s.IsDomainAvailable(domainOf(method)) || !s.isMethodAvailable(method) {
+		return DomainUnavailableError{Method: method}
+	}
+	s.stats.callStarted()
+	defer s.stats.callFinished()
+	err := s.browser.Client.Call(string(s.id), method, send, recv)
+	if isMethodNotFound(err) {
+		return DomainUnavailableError{Method: method}
+	}
+	return err
+}
+
+// IsDomainAvailable false if domain was detected as not supported by the target
+func (s Session) IsDomainAvailable(domain string) bool {
+	_, ok := s.missing.Load(domain)
+	return !ok
+}
+
+func (s Session) isMethodAvailable(method string) bool {
+	_, ok := s.missing.Load(method)
+	return !ok
+}
+
+// optional call of method - if method is not found then it's marked as missing and feature is disabled,
+// the whole domain is marked as missing only if its `enable` is not found
+func (s Session) optional(err error) error {
+	if v, ok := err.(DomainUnavailableError); ok {
+		if strings.HasSuffix(v.Method, ".enable") {
+			s.missing.Store(domainOf(v.Method), true)
+		} else {
+			s.missing.Store(v.Method, true)
 		}
-		return s.context.Err()
-	default:
-		return s.browser.Client.Call(string(s.id), method, send, recv)
+		return nil
 	}
+	return err
 }
 
 func (s Session) GetBrowserContext() *BrowserContext {
@@ -51,7 +123,7 @@ func (s Session) GetBrowserContext() *BrowserContext {
 }
 
 func (s Session) GetTargetID() target.TargetID {
-	return s.tid
+	return s.current().tid
 }
 
 func (s Session) ID() string {
@@ -63,18 +135,37 @@ func (s Session) Event() string {
 }
 
 func (s Session) Page() *Frame {
+	if current := s.current(); current.id != s.id {
+		return current.Page()
+	}
 	return &Frame{id: common.FrameId(s.tid), session: &s}
 }
 
 func (s Session) Frame(id common.FrameId) (*Frame, error) {
+	if current := s.current(); current.id != s.id {
+		return current.Frame(id)
+	}
+	if val, ok := s.oopifs.Load(id); ok {
+		return val.(*Session).Page(), nil
+	}
 	if _, ok := s.executions.Load(id); ok {
 		return &Frame{id: id, session: &s}, nil
 	}
 	return nil, NoSuchFrameError{id: id}
 }
 
+// SetContent load raw html into the main frame
+func (s Session) SetContent(html string, eventType LifecycleEventType, timeout time.Duration) error {
+	return s.Page().SetContent(html, eventType, timeout)
+}
+
+// WaitForFunction see Frame.WaitForFunction
+func (s Session) WaitForFunction(expression string, polling, timeout time.Duration) (interface{}, error) {
+	return s.Page().WaitForFunction(expression, polling, timeout)
+}
+
 func (s Session) Activate() error {
-	return s.browser.ActivateTarget(s.tid)
+	return s.browser.ActivateTarget(s.current().tid)
 }
 
 func (s Session) Update(val transport.Event) {
@@ -92,47 +183,137 @@ func (s *Session) handle(e transport.Event) error {
 		if err := json.Unmarshal(e.Params, &v); err != nil {
 			return err
 		}
-		frameID := common.FrameId((v.Context.AuxData.(map[string]interface{}))["frameId"].(string))
-		s.executions.Store(frameID, v.Context.Id)
+		auxData, _ := v.Context.AuxData.(map[string]interface{})
+		frameID, _ := auxData["frameId"].(string)
+		if isDefault, _ := auxData["isDefault"].(bool); isDefault {
+			s.executions.Store(common.FrameId(frameID), v.Context.Id)
+		} else if v.Context.Name != "" {
+			s.worlds.Store(worldKey{frame: common.FrameId(frameID), name: v.Context.Name}, v.Context.Id)
+		}
+
+	case "Runtime.executionContextDestroyed":
+		var v = runtime.ExecutionContextDestroyed{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.worlds.Range(func(key, value interface{}) bool {
+			if value.(runtime.ExecutionContextId) == v.ExecutionContextId {
+				s.worlds.Delete(key)
+			}
+			return true
+		})
+
+	case "Runtime.exceptionThrown":
+		var v = runtime.ExceptionThrown{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.pageErrors.add(v)
+
+	case "Page.javascriptDialogOpening":
+		var v = page.JavascriptDialogOpening{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.dialogs.opening(s, v)
+
+	case "Fetch.requestPaused":
+		var v = fetch.RequestPaused{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.routes.paused(s, v)
+
+	case "Network.loadingFinished", "Network.loadingFailed":
+		var v = struct {
+			RequestId network.RequestId `json:"requestId"`
+		}{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.routes.finished(v.RequestId)
+
+	case "Fetch.authRequired":
+		var v = fetch.AuthRequired{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.routes.authRequired(s, v)
+
+	case "Page.frameDetached":
+		var v = page.FrameDetached{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.executions.Delete(v.FrameId)
 
 	case "Target.targetCrashed":
 		var v = target.TargetCrashed{}
 		if err := json.Unmarshal(e.Params, &v); err != nil {
 			return err
 		}
-		return ErrTargetCrashed(v)
+		if v.TargetId == s.tid {
+			return ErrTargetCrashed(v)
+		}
 
 	case "Target.targetDestroyed":
 		var v = target.TargetDestroyed{}
 		if err := json.Unmarshal(e.Params, &v); err != nil {
 			return err
 		}
-		if v.TargetId == s.tid {
+		if v.TargetId == s.tid && s.prerender.successor.Load() == nil {
 			return ErrTargetDestroyed
 		}
 
+	case "Target.attachedToTarget":
+		var v = target.AttachedToTarget{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return err
+		}
+		s.attached(v)
+
 	case "Target.detachedFromTarget":
 		var v = target.DetachedFromTarget{}
 		if err := json.Unmarshal(e.Params, &v); err != nil {
 			return err
 		}
-		if v.SessionId == s.id {
+		if v.SessionId == s.id && s.prerender.successor.Load() == nil {
 			return ErrDetachedFromTarget
 		}
+		s.detached(v, e)
 
 	}
+	s.stats.touch()
+	s.observeNavigation(e)
+	s.observePrerender(e)
+	s.observeServiceWorkers(e)
+	s.observeEventSources(e)
+	s.observeCacheSources(e)
+	s.browser.stats.observe(s, e)
 	s.publisher.Notify(e.Method, e)
 	return nil
 }
 
+// lifecycle handles session events until target is detached, destroyed or crashed
+// then all session's resources are released: context is canceled (pending futures and actions are interrupted),
+// observers are unregistered and event pool is drained
 func (s *Session) lifecycle() {
+	var err error
 	defer func() {
+		// terminal state is set before the session is released, so state hooks observe it
+		s.lifecycleState.set(stateByExitCode(err), err)
+		s.browser.releaseSession(s)
+		s.closed()
 		s.browser.Client.Unregister(s)
 		s.exit()
+		s.publisher.UnregisterAll()
+		// no more Update after unregister from the client
+		close(s.eventPool)
+		for range s.eventPool {
+		}
 	}()
 	for e := range s.eventPool {
-		if err := s.handle(e); err != nil {
-			s.exitCode = err
+		if err = s.handle(e); err != nil {
 			return
 		}
 	}
@@ -159,8 +340,49 @@ func (s Session) Subscribe(event string, v func(e transport.Event)) (cancel func
 	}
 }
 
+//go:generate go run ./internal/eventsgen
+
+// OnEventDecodeError register hook called when params of the event can't be decoded by typed subscription
+// (e.g. OnFrameNavigated), the handler of subscription is not called for such events
+func (s Session) OnEventDecodeError(hook func(EventDecodeError)) (cancel func()) {
+	var uid = atomic.AddUint64(s.guid, 1)
+	s.decodeHooks.Store(uid, hook)
+	return func() {
+		s.decodeHooks.Delete(uid)
+	}
+}
+
+// eventDecodeFailed report the event failed to decode by typed subscription
+func (s Session) eventDecodeFailed(e transport.Event, err error) {
+	var decodeErr = EventDecodeError{Method: e.Method, Err: err}
+	s.decodeHooks.Range(func(_, hook interface{}) bool {
+		hook.(func(EventDecodeError))(decodeErr)
+		return true
+	})
+}
+
+func (s Session) observeNavigation(e transport.Event) {
+	switch e.Method {
+	case "Page.lifecycleEvent":
+		var v = page.LifecycleEvent{}
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			s.loadStates.update(v)
+		}
+	case "Page.frameStartedLoading", "Page.frameStoppedLoading":
+		var v = page.FrameStartedLoading{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.FrameId != common.FrameId(s.tid) {
+			return
+		}
+		if e.Method == "Page.frameStartedLoading" {
+			s.lifecycleState.set(StateNavigating, nil)
+		} else {
+			s.lifecycleState.set(StateReady, nil)
+		}
+	}
+}
+
 func (s Session) Close() error {
-	return s.browser.CloseTarget(s.tid)
+	return s.browser.CloseTarget(s.current().tid)
 }
 
 func (s Session) IsClosed() bool {
@@ -0,0 +1,152 @@
+package control
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+const (
+	testTargetID  = "T"
+	testSessionID = "S"
+)
+
+// call recorded call of the test session
+func call(id int, method string, params string) cdptest.Message {
+	var data = `{"id":` + strconv.Itoa(id) + `,"sessionId":"` + testSessionID + `","method":"` + method + `"`
+	if params != "" {
+		data += `,"params":` + params
+	}
+	return cdptest.Message{Direction: transport.DirectionSend, Method: method, Data: json.RawMessage(data + "}")}
+}
+
+// reply recorded reply to the call of the test session
+func reply(id int, result string) cdptest.Message {
+	return cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(`{"id":` + strconv.Itoa(id) + `,"sessionId":"` + testSessionID + `","result":` + result + `}`)}
+}
+
+// event recorded event of the test session
+func event(method string, params string) cdptest.Message {
+	return cdptest.Message{Direction: transport.DirectionRecv, Method: method, Data: json.RawMessage(`{"sessionId":"` + testSessionID + `","method":"` + method + `","params":` + params + `}`)}
+}
+
+// syncBuffer wire log written by the reading goroutine of the client
+type syncBuffer struct {
+	mx  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return b.buf.Write(p)
+}
+
+// sent params of the calls of the method sent by the client
+func (b *syncBuffer) sent(t *testing.T, method string) []json.RawMessage {
+	t.Helper()
+	b.mx.Lock()
+	messages, err := cdptest.ParseWireLog(bytes.NewReader(b.buf.Bytes()))
+	b.mx.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params []json.RawMessage
+	for _, m := range messages {
+		if m.Direction == transport.DirectionSend && m.Method == method {
+			var v = struct{ Params json.RawMessage }{}
+			_ = json.Unmarshal(m.Data, &v)
+			params = append(params, v.Params)
+		}
+	}
+	return params
+}
+
+// testSession session of target testTargetID attached to the server playing the script,
+// setup calls of the session are skipped and it's ready at once
+func testSession(t *testing.T, script ...cdptest.Message) (*Session, *cdptest.Server, *syncBuffer) {
+	t.Helper()
+	var srv = cdptest.NewServer(script, cdptest.Faults{})
+	client, err := transport.Dial(srv.URL())
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	var log = &syncBuffer{}
+	client.Timeout = time.Second * 2
+	client.Logger = &transport.WireLogger{Writer: log}
+	var session = New(client).newSession(testTargetID, testSessionID)
+	session.lifecycleState.set(StateReady, nil)
+	t.Cleanup(func() {
+		_ = client.Disconnect()
+		srv.Close()
+	})
+	return session, srv, log
+}
+
+// played wait for the server to play the whole script without conformance errors
+func played(t *testing.T, srv *cdptest.Server) {
+	t.Helper()
+	select {
+	case <-srv.Done():
+	case <-time.After(time.Second * 5):
+		t.Fatal("conversation is not played")
+	}
+	if errs := srv.Errors(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+}
+
+func TestSessionCall(t *testing.T) {
+	s, srv, _ := testSession(t,
+		call(1, "Page.enable", ""),
+		reply(1, `{}`),
+	)
+	if err := s.Call("Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Call("WebAudio.enable", nil, nil); err != (DomainUnavailableError{Method: "WebAudio.enable"}) {
+		t.Fatalf("expected DomainUnavailableError, got %v", err)
+	}
+	played(t, srv)
+}
+
+func TestSessionOptional(t *testing.T) {
+	s, srv, log := testSession(t,
+		call(1, "Page.navigate", ""),
+		reply(1, `{"frameId":"T"}`),
+	)
+	// unsupported method disables the method only
+	if err := s.optional(s.Call("Page.setLifecycleEventsEnabled", nil, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Call("Page.navigate", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Call("Page.setLifecycleEventsEnabled", nil, nil); err != (DomainUnavailableError{Method: "Page.setLifecycleEventsEnabled"}) {
+		t.Fatalf("expected DomainUnavailableError, got %v", err)
+	}
+	// unsupported enable disables the domain
+	if err := s.optional(s.Call("Network.enable", nil, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsDomainAvailable("Network") || !s.IsDomainAvailable("Page") {
+		t.Fatal("only Network domain is expected to be unavailable")
+	}
+	if err := s.Call("Network.setOffline", nil, nil); err != (DomainUnavailableError{Method: "Network.setOffline"}) {
+		t.Fatalf("expected DomainUnavailableError, got %v", err)
+	}
+	played(t, srv)
+	if n := len(log.sent(t, "Page.setLifecycleEventsEnabled")); n != 1 {
+		t.Fatalf("missing method is expected to be sent once, sent %d times", n)
+	}
+	if n := len(log.sent(t, "Network.setOffline")); n != 0 {
+		t.Fatalf("method of missing domain is sent %d times", n)
+	}
+}
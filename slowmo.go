@@ -0,0 +1,42 @@
+package control
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+func newSlowMo() *int64 {
+	var v = int64(-1)
+	return &v
+}
+
+// SetSlowMo delay every input action (click, hover, typing of text, key press etc.) and navigation of all sessions
+// by d (0 disables), e.g. to make headful debugging and recordings watchable. Sessions with own SetSlowMo are not affected
+func (b BrowserContext) SetSlowMo(d time.Duration) {
+	atomic.StoreInt64(b.slowMo, int64(d))
+}
+
+// SetSlowMo delay every input action and navigation of the session by d (0 disables),
+// negative d resets it to slow-mo of the browser context
+func (s Session) SetSlowMo(d time.Duration) {
+	if d < 0 {
+		d = -1
+	}
+	atomic.StoreInt64(s.slowMo, int64(d))
+}
+
+// SlowMo delay of input actions and navigations of the session
+func (s Session) SlowMo() time.Duration {
+	if d := atomic.LoadInt64(s.slowMo); d >= 0 {
+		return time.Duration(d)
+	}
+	return time.Duration(atomic.LoadInt64(s.browser.slowMo))
+}
+
+// slowDown sleep once at the beginning of input action or navigation if slow-mo is set,
+// it's called before input locks are taken so other actions aren't blocked by the delay
+func (s Session) slowDown() {
+	if d := s.SlowMo(); d > 0 {
+		s.Clock().Sleep(d)
+	}
+}
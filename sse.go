@@ -0,0 +1,95 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+const resourceTypeEventSource network.ResourceType = "EventSource"
+
+// EventSourceMessage message of server-sent events stream received by the page
+type EventSourceMessage struct {
+	RequestID network.RequestId
+	URL       string // url of the stream, empty if the stream was opened before the session was attached
+	Event     string // event name, "message" by default
+	ID        string
+	Data      string
+	Timestamp network.MonotonicTime
+}
+
+// observeEventSources tracks urls of EventSource streams
+func (s Session) observeEventSources(e transport.Event) {
+	switch e.Method {
+	case "Network.requestWillBeSent":
+		var v = network.RequestWillBeSent{}
+		if err := json.Unmarshal(e.Params, &v); err == nil && v.Type == resourceTypeEventSource && v.Request != nil {
+			s.eventSources.Store(v.RequestId, v.Request.Url)
+		}
+	case "Network.loadingFinished", "Network.loadingFailed":
+		var v = struct {
+			RequestId network.RequestId `json:"requestId"`
+		}{}
+		if err := json.Unmarshal(e.Params, &v); err == nil {
+			s.eventSources.Delete(v.RequestId)
+		}
+	}
+}
+
+// OnEventSourceMessage subscribe to messages of all server-sent events streams of the page
+func (n Network) OnEventSourceMessage(handler func(EventSourceMessage)) (cancel func()) {
+	return n.s.Subscribe("Network.eventSourceMessageReceived", func(e transport.Event) {
+		var v = network.EventSourceMessageReceived{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		var m = EventSourceMessage{
+			RequestID: v.RequestId,
+			Event:     v.EventName,
+			ID:        v.EventId,
+			Data:      v.Data,
+			Timestamp: v.Timestamp,
+		}
+		if url, ok := n.s.eventSources.Load(v.RequestId); ok {
+			m.URL = url.(string)
+		}
+		handler(m)
+	})
+}
+
+// EventSource returns channel of messages of streams which url matches wildcard pattern ('*' is zero or more,
+// '?' is exactly one character). Messages are dropped if the channel buffer of given size is full,
+// channel is closed by cancel
+func (n Network) EventSource(urlPattern string, buffer int) (messages <-chan EventSourceMessage, cancel func()) {
+	var (
+		url    = compileURLPattern(urlPattern)
+		ch     = make(chan EventSourceMessage, buffer)
+		mx     sync.Mutex
+		closed bool
+	)
+	unsubscribe := n.OnEventSourceMessage(func(m EventSourceMessage) {
+		if !url.MatchString(m.URL) {
+			return
+		}
+		mx.Lock()
+		defer mx.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- m:
+		default:
+		}
+	})
+	return ch, func() {
+		unsubscribe()
+		mx.Lock()
+		defer mx.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}
+}
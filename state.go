@@ -0,0 +1,111 @@
+package control
+
+import (
+	"fmt"
+	"sync"
+)
+
+type SessionState string
+
+const (
+	StateAttaching  SessionState = "attaching"
+	StateReady      SessionState = "ready"
+	StateNavigating SessionState = "navigating"
+	StateCrashed    SessionState = "crashed"
+	StateDetached   SessionState = "detached"
+	StateClosed     SessionState = "closed"
+)
+
+// IsTerminal no actions are possible in this state
+func (s SessionState) IsTerminal() bool {
+	return s == StateCrashed || s == StateDetached || s == StateClosed
+}
+
+// InvalidStateError action is not possible in current state of the session
+type InvalidStateError struct {
+	State  SessionState
+	Method string
+	Cause  error
+}
+
+func (e InvalidStateError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("can't call `%s` in session state `%s`: %s", e.Method, e.State, e.Cause)
+	}
+	return fmt.Sprintf("can't call `%s` in session state `%s`", e.Method, e.State)
+}
+
+func (e InvalidStateError) Unwrap() error {
+	return e.Cause
+}
+
+type stateMachine struct {
+	mx    sync.Mutex
+	state SessionState
+	cause error
+	seq   uint64
+	hooks map[uint64]func(from, to SessionState)
+}
+
+func newStateMachine() *stateMachine {
+	return &stateMachine{state: StateAttaching, hooks: map[uint64]func(from, to SessionState){}}
+}
+
+func (m *stateMachine) get() (SessionState, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	return m.state, m.cause
+}
+
+// set switch state, terminal states are final
+func (m *stateMachine) set(to SessionState, cause error) {
+	m.mx.Lock()
+	var from = m.state
+	if from == to || from.IsTerminal() {
+		m.mx.Unlock()
+		return
+	}
+	m.state = to
+	m.cause = cause
+	var hooks = make([]func(from, to SessionState), 0, len(m.hooks))
+	for _, h := range m.hooks {
+		hooks = append(hooks, h)
+	}
+	m.mx.Unlock()
+	for _, h := range hooks {
+		h(from, to)
+	}
+}
+
+// State current lifecycle state of the session
+func (s Session) State() SessionState {
+	state, _ := s.lifecycleState.get()
+	return state
+}
+
+// OnStateChange register hook called on every state transition
+func (s Session) OnStateChange(hook func(from, to SessionState)) (cancel func()) {
+	var m = s.lifecycleState
+	m.mx.Lock()
+	m.seq++
+	var id = m.seq
+	m.hooks[id] = hook
+	m.mx.Unlock()
+	return func() {
+		m.mx.Lock()
+		delete(m.hooks, id)
+		m.mx.Unlock()
+	}
+}
+
+func stateByExitCode(err error) SessionState {
+	switch err.(type) {
+	case ErrTargetCrashed:
+		return StateCrashed
+	}
+	switch err {
+	case ErrDetachedFromTarget:
+		return StateDetached
+	}
+	return StateClosed
+}
@@ -0,0 +1,115 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+type transition struct{ from, to SessionState }
+
+func TestStateMachine(t *testing.T) {
+	var cases = []struct {
+		name        string
+		set         []SessionState
+		expect      SessionState
+		transitions []transition
+	}{
+		{
+			name:        "setup and navigation",
+			set:         []SessionState{StateReady, StateNavigating, StateNavigating, StateReady},
+			expect:      StateReady,
+			transitions: []transition{{StateAttaching, StateReady}, {StateReady, StateNavigating}, {StateNavigating, StateReady}},
+		},
+		{
+			name:        "terminal state is final",
+			set:         []SessionState{StateReady, StateCrashed, StateReady, StateDetached},
+			expect:      StateCrashed,
+			transitions: []transition{{StateAttaching, StateReady}, {StateReady, StateCrashed}},
+		},
+		{
+			name:        "setup failed",
+			set:         []SessionState{StateClosed, StateReady},
+			expect:      StateClosed,
+			transitions: []transition{{StateAttaching, StateClosed}},
+		},
+	}
+	for _, c := range cases {
+		var (
+			m           = newStateMachine()
+			transitions []transition
+		)
+		m.hooks[1] = func(from, to SessionState) { transitions = append(transitions, transition{from, to}) }
+		for _, state := range c.set {
+			m.set(state, nil)
+		}
+		if state, _ := m.get(); state != c.expect || !reflect.DeepEqual(transitions, c.transitions) {
+			t.Errorf("%s: expected %s %v, got %s %v", c.name, c.expect, c.transitions, state, transitions)
+		}
+	}
+}
+
+func TestStateByExitCode(t *testing.T) {
+	var cases = []struct {
+		err    error
+		expect SessionState
+	}{
+		{ErrTargetCrashed{TargetId: "T"}, StateCrashed},
+		{ErrDetachedFromTarget, StateDetached},
+		{ErrTargetDestroyed, StateClosed},
+		{errors.New("malformed event"), StateClosed},
+		{nil, StateClosed},
+	}
+	for _, c := range cases {
+		if got := stateByExitCode(c.err); got != c.expect {
+			t.Errorf("%v: expected %s, got %s", c.err, c.expect, got)
+		}
+	}
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	detached, _ := json.Marshal(map[string]interface{}{
+		"method": "Target.detachedFromTarget",
+		"params": target.DetachedFromTarget{SessionId: testSessionID},
+	})
+	s, srv, _ := testSession(t,
+		call(1, "Page.enable", ""),
+		reply(1, `{}`),
+		event("Page.frameStartedLoading", `{"frameId":"child"}`),
+		event("Page.frameStartedLoading", `{"frameId":"`+testTargetID+`"}`),
+		event("Page.frameStoppedLoading", `{"frameId":"`+testTargetID+`"}`),
+		cdptest.Message{Direction: transport.DirectionRecv, Method: "Target.detachedFromTarget", Data: detached},
+	)
+	var changes = make(chan transition, 10)
+	s.OnStateChange(func(from, to SessionState) { changes <- transition{from, to} })
+	if err := s.Call("Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	var expect = []transition{{StateReady, StateNavigating}, {StateNavigating, StateReady}, {StateReady, StateDetached}}
+	for _, e := range expect {
+		select {
+		case got := <-changes:
+			if got != e {
+				t.Fatalf("expected transition %v, got %v", e, got)
+			}
+		case <-time.After(time.Second * 2):
+			t.Fatalf("no transition %v", e)
+		}
+	}
+	played(t, srv)
+	var err = s.Call("Page.enable", nil, nil)
+	if e, ok := err.(InvalidStateError); !ok || e.State != StateDetached || e.Cause != ErrDetachedFromTarget {
+		t.Fatalf("expected InvalidStateError of detached session, got %v", err)
+	}
+	select {
+	case <-s.context.Done():
+	case <-time.After(time.Second * 2):
+		t.Fatal("context of detached session is not done")
+	}
+}
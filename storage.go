@@ -0,0 +1,301 @@
+package control
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/domstorage"
+	"github.com/ecwid/control/protocol/fetch"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/protocol/storage"
+	"github.com/ecwid/control/protocol/target"
+)
+
+// OriginStorage localStorage and sessionStorage items of the origin
+type OriginStorage struct {
+	Origin         string            `json:"origin"`
+	LocalStorage   map[string]string `json:"localStorage,omitempty"`
+	SessionStorage map[string]string `json:"sessionStorage,omitempty"`
+}
+
+// StorageState cookies and web storage of browser context
+type StorageState struct {
+	Cookies []*network.Cookie `json:"cookies"`
+	Origins []*OriginStorage  `json:"origins"`
+}
+
+func getStorageItems(s *Session, origin string, local bool) (map[string]string, error) {
+	val, err := domstorage.GetDOMStorageItems(s, domstorage.GetDOMStorageItemsArgs{
+		StorageId: &domstorage.StorageId{SecurityOrigin: origin, IsLocalStorage: local},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var items = make(map[string]string, len(val.Entries))
+	for _, entry := range val.Entries {
+		if len(entry) == 2 {
+			items[entry[0]] = entry[1]
+		}
+	}
+	return items, nil
+}
+
+func setStorageItems(s *Session, origin string, local bool, items map[string]string) error {
+	var id = &domstorage.StorageId{SecurityOrigin: origin, IsLocalStorage: local}
+	for key, value := range items {
+		if err := domstorage.SetDOMStorageItem(s, domstorage.SetDOMStorageItemArgs{StorageId: id, Key: key, Value: value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storageOrigin returns origin or security origin of the main frame if origin is empty
+func (s Session) storageOrigin(origin string) (string, error) {
+	if origin != "" {
+		return origin, nil
+	}
+	val, err := page.GetFrameTree(s)
+	if err != nil {
+		return "", err
+	}
+	return val.FrameTree.Frame.SecurityOrigin, nil
+}
+
+// GetLocalStorage returns localStorage items of the origin (empty origin means origin of the page)
+func (s Session) GetLocalStorage(origin string) (map[string]string, error) {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	return getStorageItems(&s, origin, true)
+}
+
+// SetLocalStorage set localStorage items of the origin (empty origin means origin of the page)
+func (s Session) SetLocalStorage(origin string, items map[string]string) error {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return err
+	}
+	return setStorageItems(&s, origin, true, items)
+}
+
+// GetSessionStorage returns sessionStorage items of the origin (empty origin means origin of the page)
+func (s Session) GetSessionStorage(origin string) (map[string]string, error) {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	return getStorageItems(&s, origin, false)
+}
+
+// SetSessionStorage set sessionStorage items of the origin (empty origin means origin of the page)
+func (s Session) SetSessionStorage(origin string, items map[string]string) error {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return err
+	}
+	return setStorageItems(&s, origin, false, items)
+}
+
+// RemoveStorageItem remove item of localStorage (local = true) or sessionStorage of the origin
+func (s Session) RemoveStorageItem(origin string, local bool, key string) error {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return err
+	}
+	return domstorage.RemoveDOMStorageItem(s, domstorage.RemoveDOMStorageItemArgs{
+		StorageId: &domstorage.StorageId{SecurityOrigin: origin, IsLocalStorage: local},
+		Key:       key,
+	})
+}
+
+// ClearStorage clear both localStorage and sessionStorage of the origin (empty origin means origin of the page)
+func (s Session) ClearStorage(origin string) error {
+	origin, err := s.storageOrigin(origin)
+	if err != nil {
+		return err
+	}
+	for _, local := range []bool{true, false} {
+		err = domstorage.Clear(s, domstorage.ClearArgs{
+			StorageId: &domstorage.StorageId{SecurityOrigin: origin, IsLocalStorage: local},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// origins security origins of all frames of the page
+func (s Session) origins() ([]string, error) {
+	val, err := page.GetFrameTree(s)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		origins []string
+		walk    func(tree *page.FrameTree)
+	)
+	walk = func(tree *page.FrameTree) {
+		if o := tree.Frame.SecurityOrigin; strings.HasPrefix(o, "http://") || strings.HasPrefix(o, "https://") {
+			origins = append(origins, o)
+		}
+		for _, child := range tree.ChildFrames {
+			walk(child)
+		}
+	}
+	walk(val.FrameTree)
+	return origins, nil
+}
+
+// sessionsOf attached sessions of the browser context (empty id means default context)
+func (b BrowserContext) sessionsOf(contextID common.BrowserContextID) ([]*Session, error) {
+	contexts, err := target.GetBrowserContexts(b)
+	if err != nil {
+		return nil, err
+	}
+	var incognito = map[common.BrowserContextID]bool{}
+	for _, id := range contexts.BrowserContextIds {
+		incognito[id] = true
+	}
+	var sessions []*Session
+	b.sessions.Range(func(key, value interface{}) bool {
+		var s = value.(*Session)
+		info, err := b.targetInfo(s.tid)
+		if err != nil || info.Type != "page" && info.Type != "iframe" {
+			return true
+		}
+		if info.BrowserContextId == contextID || contextID == "" && !incognito[info.BrowserContextId] {
+			sessions = append(sessions, s)
+		}
+		return true
+	})
+	return sessions, nil
+}
+
+// GetStorageState cookies of the browser context (empty id means default context) and web storage
+// of all origins loaded in its attached pages
+func (b BrowserContext) GetStorageState(contextID common.BrowserContextID) (*StorageState, error) {
+	cookies, err := storage.GetCookies(b, storage.GetCookiesArgs{BrowserContextId: contextID})
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := b.sessionsOf(contextID)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		state   = &StorageState{Cookies: cookies.Cookies}
+		origins = map[string]*OriginStorage{}
+	)
+	for _, s := range sessions {
+		list, err := s.origins()
+		if err != nil {
+			continue // page is closing
+		}
+		for _, origin := range list {
+			var v, ok = origins[origin]
+			if !ok {
+				v = &OriginStorage{Origin: origin, LocalStorage: map[string]string{}, SessionStorage: map[string]string{}}
+				origins[origin] = v
+			}
+			local, err := getStorageItems(s, origin, true)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range local {
+				v.LocalStorage[key] = value
+			}
+			session, err := getStorageItems(s, origin, false)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range session {
+				v.SessionStorage[key] = value
+			}
+		}
+	}
+	for _, v := range origins {
+		state.Origins = append(state.Origins, v)
+	}
+	sort.Slice(state.Origins, func(i, j int) bool {
+		return state.Origins[i].Origin < state.Origins[j].Origin
+	})
+	return state, nil
+}
+
+// SaveStorageState write storage state of the default browser context to the file as JSON
+func (b BrowserContext) SaveStorageState(path string) error {
+	state, err := b.GetStorageState("")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// NewSessionWithStorageState creates page in the default browser context with storage state saved by SaveStorageState
+func (b *BrowserContext) NewSessionWithStorageState(path string) (*Session, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state = &StorageState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	session, err := b.CreatePageTargetIn("", Blank, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = session.SetStorageState(state); err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// SetStorageState set cookies and restore web storage of every origin. Web storage is available only for
+// a document of the origin, so the page visits stub documents of the origins and returns to about:blank
+func (s Session) SetStorageState(state *StorageState) error {
+	var params = make([]*network.CookieParam, len(state.Cookies))
+	for i, c := range state.Cookies {
+		params[i] = NewCookieParam(c)
+	}
+	if len(params) > 0 {
+		if err := s.Network.SetCookies(params...); err != nil {
+			return err
+		}
+	}
+	if len(state.Origins) == 0 {
+		return nil
+	}
+	cancel, err := s.Intercept(fetch.RequestPattern{ResourceType: "Document"}, func(route *Route) {
+		_ = route.Fulfill(200, map[string]string{"Content-Type": "text/html"}, []byte("<html></html>"))
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	for _, v := range state.Origins {
+		if err = s.Page().Navigate(v.Origin+"/", LifecycleLoad, s.browser.Client.Timeout); err != nil && err != ErrAlreadyNavigated {
+			return err
+		}
+		if err = setStorageItems(&s, v.Origin, true, v.LocalStorage); err != nil {
+			return err
+		}
+		if err = setStorageItems(&s, v.Origin, false, v.SessionStorage); err != nil {
+			return err
+		}
+	}
+	cancel()
+	return s.Page().Navigate(Blank, LifecycleLoad, s.browser.Client.Timeout)
+}
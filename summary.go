@@ -0,0 +1,105 @@
+package control
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/transport"
+)
+
+// RunSummary machine-readable summary of the run
+type RunSummary struct {
+	RunID            string         `json:"runId,omitempty"`
+	Started          time.Time      `json:"started"`
+	Duration         time.Duration  `json:"duration"`
+	SessionsCreated  int            `json:"sessionsCreated"`
+	SessionsDuration time.Duration  `json:"sessionsDuration"` // total lifetime of closed sessions
+	Navigations      int            `json:"navigations"`
+	Actions          int            `json:"actions"`
+	Failures         map[string]int `json:"failures"` // failed actions by error kind
+	BytesTransferred float64        `json:"bytesTransferred"`
+}
+
+type runStats struct {
+	mx               sync.Mutex
+	started          time.Time
+	sessionsCreated  int
+	sessionsDuration time.Duration
+	navigations      int
+	bytesTransferred float64
+}
+
+func newRunStats() *runStats {
+	return &runStats{started: time.Now()}
+}
+
+func (r *runStats) sessionCreated() func() {
+	var created = time.Now()
+	r.mx.Lock()
+	r.sessionsCreated++
+	r.mx.Unlock()
+	return func() {
+		r.mx.Lock()
+		r.sessionsDuration += time.Since(created)
+		r.mx.Unlock()
+	}
+}
+
+func (r *runStats) observe(s *Session, e transport.Event) {
+	switch e.Method {
+	case "Page.frameNavigated":
+		var v = page.FrameNavigated{}
+		if err := json.Unmarshal(e.Params, &v); err != nil || v.Frame.Id != common.FrameId(s.tid) {
+			return
+		}
+		r.mx.Lock()
+		r.navigations++
+		r.mx.Unlock()
+	case "Network.loadingFinished":
+		var v = network.LoadingFinished{}
+		if err := json.Unmarshal(e.Params, &v); err != nil {
+			return
+		}
+		r.mx.Lock()
+		r.bytesTransferred += v.EncodedDataLength
+		r.mx.Unlock()
+	}
+}
+
+// RunSummary returns summary of the run so far
+func (b BrowserContext) RunSummary() RunSummary {
+	b.stats.mx.Lock()
+	var summary = RunSummary{
+		RunID:            b.RunID,
+		Started:          b.stats.started,
+		Duration:         time.Since(b.stats.started),
+		SessionsCreated:  b.stats.sessionsCreated,
+		SessionsDuration: b.stats.sessionsDuration,
+		Navigations:      b.stats.navigations,
+		BytesTransferred: b.stats.bytesTransferred,
+		Failures:         map[string]int{},
+	}
+	b.stats.mx.Unlock()
+	b.history.mx.Lock()
+	for _, v := range b.history.elements {
+		for _, n := range v.Actions {
+			summary.Actions += n
+		}
+		for kind, n := range v.Errors {
+			summary.Failures[kind] += n
+		}
+	}
+	b.history.mx.Unlock()
+	return summary
+}
+
+func (b BrowserContext) writeSummary() error {
+	if b.Summary == nil {
+		return nil
+	}
+	return json.NewEncoder(b.Summary).Encode(b.RunSummary())
+}
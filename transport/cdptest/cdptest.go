@@ -0,0 +1,363 @@
+// Package cdptest replays recorded CDP conversations against the client with injected faults
+// (reordering of replies and events, truncated frames and error responses) to verify resilience of the client
+// and the layers above it. Conversation can be recorded by transport.WireLogger without truncation and hex dumps
+package cdptest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecwid/control/transport"
+	"github.com/gorilla/websocket"
+)
+
+// codeNotRecorded error code of replies to calls that the conversation doesn't contain
+const codeNotRecorded = -32601
+
+// Message recorded protocol message, Direction is transport.DirectionSend or transport.DirectionRecv
+type Message struct {
+	Direction string
+	Method    string
+	Data      json.RawMessage
+}
+
+type header struct {
+	ID        uint64 `json:"id"`
+	Method    string `json:"method"`
+	SessionID string `json:"sessionId"`
+}
+
+func (m Message) id() uint64 {
+	var h = header{}
+	_ = json.Unmarshal(m.Data, &h)
+	return h.ID
+}
+
+// ParseWireLog read conversation logged by transport.WireLogger, truncated and not JSON messages are skipped
+func ParseWireLog(r io.Reader) ([]Message, error) {
+	var (
+		scanner  = bufio.NewScanner(r)
+		messages []Message
+	)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		// 15:04:05.000 [run=ID ]-> Method {...}
+		var line = scanner.Text()
+		if fields := strings.SplitN(line, " ", 3); len(fields) == 3 && strings.HasPrefix(fields[1], "run=") {
+			line = fields[0] + " " + fields[2]
+		}
+		var fields = strings.SplitN(line, " ", 4)
+		if len(fields) != 4 || fields[1] != transport.DirectionSend && fields[1] != transport.DirectionRecv {
+			continue
+		}
+		var data = []byte(fields[3])
+		if !json.Valid(data) {
+			continue
+		}
+		messages = append(messages, Message{Direction: fields[1], Method: fields[2], Data: data})
+	}
+	return messages, scanner.Err()
+}
+
+// Faults probabilities (0..1) of injected faults, random source is seeded by Seed to reproduce a failure
+type Faults struct {
+	Seed     int64
+	Reorder  float64 // swap adjacent replies and events
+	Truncate float64 // send half of the frame
+	Error    float64 // replace result of reply with error response
+}
+
+// Server websocket server that plays the conversation for the first connection:
+// recorded replies and events are sent after the client sent the call that preceded them in conversation,
+// calls missing in the rest of the conversation are answered immediately with method not found error
+type Server struct {
+	// CallTimeout how long to wait for the next recorded call of the client
+	CallTimeout time.Duration
+
+	script   []Message
+	faults   Faults
+	rand     *rand.Rand
+	http     *httptest.Server
+	once     sync.Once
+	done     chan struct{}
+	closed   chan struct{}
+	mx       sync.Mutex
+	write    sync.Mutex
+	errs     []error
+	unknown  []Message
+	injected int
+}
+
+// NewServer starts server playing the conversation
+func NewServer(script []Message, faults Faults) *Server {
+	var s = &Server{
+		CallTimeout: time.Second * 5,
+		script:      script,
+		faults:      faults,
+		rand:        rand.New(rand.NewSource(faults.Seed)),
+		done:        make(chan struct{}),
+		closed:      make(chan struct{}),
+	}
+	s.http = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL websocket url for transport.Dial
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.http.URL, "http")
+}
+
+// Done closed when the whole conversation was played
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// Errors conformance errors, e.g. recorded call was not sent by the client
+func (s *Server) Errors() []error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return append([]error(nil), s.errs...)
+}
+
+// Unknown calls sent by the client that the conversation doesn't contain
+func (s *Server) Unknown() []Message {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return append([]Message(nil), s.unknown...)
+}
+
+// Injected number of injected faults
+func (s *Server) Injected() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.injected
+}
+
+func (s *Server) Close() {
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	s.http.CloseClientConnections()
+	s.http.Close()
+}
+
+func (s *Server) fail(err error) {
+	s.mx.Lock()
+	s.errs = append(s.errs, err)
+	s.mx.Unlock()
+}
+
+func (s *Server) chance(p float64) bool {
+	if p <= 0 || s.rand.Float64() >= p {
+		return false
+	}
+	s.mx.Lock()
+	s.injected++
+	s.mx.Unlock()
+	return true
+}
+
+type received struct {
+	header
+	data json.RawMessage
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	var first = false
+	s.once.Do(func() { first = true })
+	if !first {
+		http.Error(w, "conversation is already played", http.StatusConflict)
+		return
+	}
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	defer conn.Close()
+	var calls = make(chan received, 1024)
+	go func() {
+		defer close(calls)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var call = received{data: data}
+			if err = json.Unmarshal(data, &call.header); err != nil {
+				s.fail(fmt.Errorf("malformed call: %w", err))
+				continue
+			}
+			calls <- call
+		}
+	}()
+	var pending = s.play(conn, calls)
+	close(s.done)
+	for _, call := range pending {
+		s.unknownCall(conn, call)
+	}
+	for {
+		select {
+		case call, ok := <-calls:
+			if !ok {
+				return
+			}
+			s.unknownCall(conn, call)
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// play walks the conversation, returns received calls that were not matched
+func (s *Server) play(conn *websocket.Conn, calls chan received) []received {
+	var (
+		ids       = map[uint64]uint64{} // actual ids of recorded calls
+		remaining = map[string]int{}    // recorded calls of the method not awaited yet
+		pending   []received
+		out       []json.RawMessage
+	)
+	for _, m := range s.script {
+		if m.Direction == transport.DirectionSend {
+			remaining[m.Method]++
+		}
+	}
+	for _, m := range s.script {
+		if m.Direction == transport.DirectionRecv {
+			if data, ok := s.reply(m, ids); ok {
+				out = append(out, data)
+			}
+			continue
+		}
+		s.flush(conn, out)
+		out = nil
+		call, rest, err := s.await(conn, m.Method, pending, remaining, calls)
+		pending = rest
+		remaining[m.Method]--
+		if err != nil {
+			s.fail(err)
+			if err == errClosed {
+				return pending
+			}
+			continue
+		}
+		ids[m.id()] = call.ID
+	}
+	s.flush(conn, out)
+	return pending
+}
+
+var errClosed = errors.New("connection closed")
+
+// await the call of the method, calls recorded later in the conversation are kept pending
+// and other calls are answered as unknown
+func (s *Server) await(conn *websocket.Conn, method string, pending []received, remaining map[string]int, calls chan received) (received, []received, error) {
+	for i, call := range pending {
+		if call.Method == method {
+			return call, append(pending[:i:i], pending[i+1:]...), nil
+		}
+	}
+	var timeout = time.NewTimer(s.CallTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case call, ok := <-calls:
+			if !ok {
+				return received{}, pending, errClosed
+			}
+			if call.Method == method {
+				return call, pending, nil
+			}
+			if countMethod(pending, call.Method) < remaining[call.Method] {
+				pending = append(pending, call)
+			} else {
+				s.unknownCall(conn, call)
+			}
+		case <-timeout.C:
+			return received{}, pending, fmt.Errorf("recorded call `%s` was not sent in %s", method, s.CallTimeout)
+		case <-s.closed:
+			return received{}, pending, errClosed
+		}
+	}
+}
+
+func countMethod(calls []received, method string) int {
+	var n = 0
+	for _, call := range calls {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// reply recorded reply with id of the actual call or event, reply to call that wasn't sent is skipped
+func (s *Server) reply(m Message, ids map[uint64]uint64) (json.RawMessage, bool) {
+	var recorded = m.id()
+	if recorded == 0 {
+		return m.Data, true
+	}
+	actual, ok := ids[recorded]
+	if !ok {
+		return nil, false
+	}
+	var fields = map[string]json.RawMessage{}
+	if err := json.Unmarshal(m.Data, &fields); err != nil {
+		s.fail(err)
+		return nil, false
+	}
+	fields["id"], _ = json.Marshal(actual)
+	if s.chance(s.faults.Error) {
+		delete(fields, "result")
+		fields["error"], _ = json.Marshal(transport.Error{Code: -32000, Message: "injected error"})
+	}
+	data, _ := json.Marshal(fields)
+	return data, true
+}
+
+// flush send replies and events injecting reorderings and truncated frames
+func (s *Server) flush(conn *websocket.Conn, out []json.RawMessage) {
+	for i := 0; i+1 < len(out); i++ {
+		if s.chance(s.faults.Reorder) {
+			out[i], out[i+1] = out[i+1], out[i]
+			i++
+		}
+	}
+	for _, data := range out {
+		if s.chance(s.faults.Truncate) {
+			data = data[:len(data)/2]
+		}
+		s.send(conn, data)
+	}
+}
+
+func (s *Server) send(conn *websocket.Conn, data []byte) {
+	s.write.Lock()
+	defer s.write.Unlock()
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		s.fail(err)
+	}
+}
+
+func (s *Server) unknownCall(conn *websocket.Conn, call received) {
+	s.mx.Lock()
+	s.unknown = append(s.unknown, Message{Direction: transport.DirectionSend, Method: call.Method, Data: call.data})
+	s.mx.Unlock()
+	data, _ := json.Marshal(transport.Reply{
+		ID:        call.ID,
+		SessionID: call.SessionID,
+		Error:     &transport.Error{Code: codeNotRecorded, Message: "'" + call.Method + "' wasn't found"},
+	})
+	s.send(conn, data)
+}
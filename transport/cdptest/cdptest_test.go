@@ -0,0 +1,207 @@
+package cdptest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+func send(data string) cdptest.Message {
+	var h = struct{ Method string }{}
+	_ = json.Unmarshal([]byte(data), &h)
+	return cdptest.Message{Direction: transport.DirectionSend, Method: h.Method, Data: json.RawMessage(data)}
+}
+
+func recv(data string) cdptest.Message {
+	return cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(data)}
+}
+
+// dial connect to the server playing the script, callTimeout of the server if not 0
+func dial(t *testing.T, script []cdptest.Message, faults cdptest.Faults, callTimeout time.Duration) (*cdptest.Server, *transport.Client) {
+	t.Helper()
+	var srv = cdptest.NewServer(script, faults)
+	if callTimeout > 0 {
+		srv.CallTimeout = callTimeout
+	}
+	client, err := transport.Dial(srv.URL())
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	client.Timeout = time.Second * 2
+	t.Cleanup(func() {
+		_ = client.Disconnect()
+		srv.Close()
+	})
+	return srv, client
+}
+
+func wait(t *testing.T, srv *cdptest.Server) {
+	t.Helper()
+	select {
+	case <-srv.Done():
+	case <-time.After(time.Second * 5):
+		t.Fatal("conversation is not played")
+	}
+}
+
+func notRecorded(t *testing.T, err error) {
+	t.Helper()
+	if e, ok := err.(*transport.Error); !ok || e.Code != -32601 {
+		t.Fatalf("expected not recorded error, got %v", err)
+	}
+}
+
+func TestParseWireLog(t *testing.T) {
+	const log = `15:04:05.000 -> Page.navigate {"id":1,"method":"Page.navigate","params":{"url":"about:blank"}}
+15:04:05.001 run=ci-42 <- Page.navigate {"id":1,"result":{"frameId":"F"}}
+15:04:05.002 <- Page.frameNavigated {"method":"Page.frameNavigated","params":{"frame":{}}... (100 bytes truncated)
+garbage
+`
+	messages, err := cdptest.ParseWireLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0].Direction != transport.DirectionSend || messages[1].Method != "Page.navigate" {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+}
+
+func TestServerReplaysConversation(t *testing.T) {
+	srv, client := dial(t, []cdptest.Message{
+		send(`{"id":7,"method":"Target.getTargets"}`),
+		recv(`{"id":7,"result":{"targetInfos":[{"targetId":"T"}]}}`),
+		recv(`{"method":"Target.targetCreated","params":{"targetInfo":{"targetId":"T"}}}`),
+	}, cdptest.Faults{}, 0)
+	var events = make(chan transport.Event, 1)
+	client.Register(transport.NewSimpleObserver("test", "", func(e transport.Event) { events <- e }))
+
+	var val = struct {
+		TargetInfos []struct{ TargetId string }
+	}{}
+	if err := client.Call("", "Target.getTargets", nil, &val); err != nil {
+		t.Fatal(err)
+	}
+	if len(val.TargetInfos) != 1 || val.TargetInfos[0].TargetId != "T" {
+		t.Fatalf("unexpected result %+v", val)
+	}
+	select {
+	case e := <-events:
+		if e.Method != "Target.targetCreated" {
+			t.Fatalf("unexpected event %s", e.Method)
+		}
+	case <-time.After(time.Second * 2):
+		t.Fatal("event is not received")
+	}
+	wait(t, srv)
+	if errs := srv.Errors(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+}
+
+func TestServerAnswersUnrecordedCallImmediately(t *testing.T) {
+	srv, client := dial(t, []cdptest.Message{
+		send(`{"id":1,"method":"Page.enable"}`),
+		recv(`{"id":1,"result":{}}`),
+	}, cdptest.Faults{}, time.Second*10)
+
+	var start = time.Now()
+	notRecorded(t, client.Call("", "Unknown.method", nil, nil))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("unrecorded call is answered in %s", elapsed)
+	}
+	if err := client.Call("", "Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, srv)
+	if unknown := srv.Unknown(); len(unknown) != 1 || unknown[0].Method != "Unknown.method" {
+		t.Fatalf("unexpected unknown calls %+v", unknown)
+	}
+}
+
+func TestServerKeepsReorderedCalls(t *testing.T) {
+	srv, client := dial(t, []cdptest.Message{
+		send(`{"id":1,"method":"Page.enable"}`),
+		recv(`{"id":1,"result":{}}`),
+		send(`{"id":2,"method":"Runtime.enable"}`),
+		recv(`{"id":2,"result":{}}`),
+	}, cdptest.Faults{}, 0)
+	var runtime = make(chan error, 1)
+	go func() { runtime <- client.Call("", "Runtime.enable", nil, nil) }()
+	if err := client.Call("", "Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-runtime; err != nil {
+		t.Fatal(err)
+	}
+	wait(t, srv)
+	if errs, unknown := srv.Errors(), srv.Unknown(); len(errs) != 0 || len(unknown) != 0 {
+		t.Fatal(errs, unknown)
+	}
+}
+
+func TestServerReportsMissingCall(t *testing.T) {
+	srv, _ := dial(t, []cdptest.Message{
+		send(`{"id":1,"method":"Page.enable"}`),
+		recv(`{"id":1,"result":{}}`),
+	}, cdptest.Faults{}, time.Millisecond*100)
+	wait(t, srv)
+	if errs := srv.Errors(); len(errs) != 1 {
+		t.Fatalf("expected error of missing call, got %v", errs)
+	}
+}
+
+func TestClientSurvivesTruncatedFrames(t *testing.T) {
+	srv, client := dial(t, []cdptest.Message{
+		recv(`{"method":"Page.frameNavigated","params":{"frame":{"id":"F","url":"about:blank"}}}`),
+		recv(`{"method":"Page.loadEventFired","params":{"timestamp":1}}`),
+	}, cdptest.Faults{Truncate: 1}, 0)
+	wait(t, srv)
+	// truncated frames are sent before the reply to this call
+	notRecorded(t, client.Call("", "Unknown.method", nil, nil))
+	if n := srv.Injected(); n != 2 {
+		t.Fatalf("expected 2 injected faults, got %d", n)
+	}
+}
+
+func TestInjectedErrorResponse(t *testing.T) {
+	srv, client := dial(t, []cdptest.Message{
+		send(`{"id":1,"method":"Page.enable"}`),
+		recv(`{"id":1,"result":{}}`),
+	}, cdptest.Faults{Error: 1}, 0)
+	var err = client.Call("", "Page.enable", nil, nil)
+	if e, ok := err.(*transport.Error); !ok || e.Message != "injected error" {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	wait(t, srv)
+}
+
+func TestWireLogRoundTrip(t *testing.T) {
+	var script = []cdptest.Message{
+		send(`{"id":1,"method":"Page.enable"}`),
+		recv(`{"id":1,"result":{}}`),
+	}
+	srv, client := dial(t, script, cdptest.Faults{}, 0)
+	var log = &bytes.Buffer{}
+	client.Logger = &transport.WireLogger{Writer: log}
+	client.Logger.SetRunID("ci-42")
+	if err := client.Call("", "Page.enable", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, srv)
+	if !strings.Contains(log.String(), " run=ci-42 -> Page.enable ") {
+		t.Fatalf("run id is not logged: %s", log)
+	}
+	messages, err := cdptest.ParseWireLog(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0].Method != "Page.enable" || messages[1].Direction != transport.DirectionRecv {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// methodMalformed logged method of frames that are not valid JSON
+const methodMalformed = "malformed"
+
 type Client struct {
 	*Publisher
 	conn      *websocket.Conn
@@ -19,6 +22,9 @@ type Client struct {
 	mutex     sync.Mutex
 	closed    bool
 	Timeout   time.Duration
+	Logger    *WireLogger
+	// Clock of call timeouts, it's also the default clock of sessions
+	Clock Clock
 }
 
 func Dial(url string) (*Client, error) {
@@ -38,6 +44,7 @@ func Dial(url string) (*Client, error) {
 		seq:       1,
 		pending:   map[uint64]*Call{},
 		Timeout:   time.Second * 60,
+		Clock:     SystemClock,
 	}
 	go client.reading()
 	return client, nil
@@ -52,6 +59,13 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// Disconnect close websocket connection without closing the browser
+func (c *Client) Disconnect() error {
+	err := c.conn.Close()
+	c.terminate(ErrShutdown)
+	return err
+}
+
 func (c *Client) Call(sessionID, method string, args, value interface{}) error {
 	var call = &Call{
 		SessionID: sessionID,
@@ -62,7 +76,7 @@ func (c *Client) Call(sessionID, method string, args, value interface{}) error {
 	if err := c.send(call); err != nil {
 		return err
 	}
-	var timeout = time.NewTimer(c.Timeout)
+	var timeout = c.Clock.NewTimer(c.Timeout)
 	defer timeout.Stop()
 
 	var r Reply
@@ -71,7 +85,7 @@ func (c *Client) Call(sessionID, method string, args, value interface{}) error {
 		if r.Error != nil {
 			return r.Error
 		}
-	case <-timeout.C:
+	case <-timeout.C():
 		return CallTimeoutError{
 			Call:    call,
 			Timeout: c.Timeout,
@@ -98,7 +112,12 @@ func (c *Client) send(call *Call) error {
 	c.pending[seq] = call
 	c.mutex.Unlock()
 
-	if err := c.conn.WriteJSON(call); err != nil {
+	b, err := json.Marshal(call)
+	if err == nil {
+		c.Logger.log(DirectionSend, call.Method, b)
+		err = c.conn.WriteMessage(websocket.TextMessage, b)
+	}
+	if err != nil {
 		c.mutex.Lock()
 		delete(c.pending, seq)
 		c.mutex.Unlock()
@@ -120,10 +139,17 @@ func (c *Client) terminate(err error) {
 
 func (c *Client) read() error {
 	reply := Reply{}
-	if err := c.conn.ReadJSON(&reply); err != nil {
+	_, b, err := c.conn.ReadMessage()
+	if err != nil {
 		return err
 	}
+	if err = json.Unmarshal(b, &reply); err != nil {
+		// malformed frame is dropped, the connection is still usable
+		c.Logger.log(DirectionRecv, methodMalformed, b)
+		return nil
+	}
 	if reply.ID == 0 {
+		c.Logger.log(DirectionRecv, reply.Method, b)
 		c.Notify(reply.SessionID, Event{Method: reply.Method, Params: reply.Params})
 	} else {
 		c.mutex.Lock()
@@ -133,6 +159,7 @@ func (c *Client) read() error {
 		if call == nil {
 			return errors.New("reading error body")
 		}
+		c.Logger.log(DirectionRecv, call.Method, b)
 		call.done(reply)
 	}
 	return nil
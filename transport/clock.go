@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock source of time for timeouts, polling and delays, it can be replaced by ManualClock
+// to fast-forward waits in tests
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer see time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker see time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock real time clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct{ *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+type systemTicker struct{ *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// ManualClock clock that is moved forward only by Advance
+type ManualClock struct {
+	mx      sync.Mutex
+	now     time.Time
+	seq     uint64
+	waiters map[uint64]*manualTimer
+}
+
+// NewManualClock creates manual clock started at the given time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now, waiters: map[uint64]*manualTimer{}}
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	id       uint64
+	deadline time.Time
+	period   time.Duration // ticker period, zero for timer
+	c        chan time.Time
+}
+
+func (t *manualTimer) C() <-chan time.Time { return t.c }
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mx.Lock()
+	defer t.clock.mx.Unlock()
+	_, active := t.clock.waiters[t.id]
+	delete(t.clock.waiters, t.id)
+	return active
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	t.clock.mx.Lock()
+	defer t.clock.mx.Unlock()
+	_, active := t.clock.waiters[t.id]
+	t.deadline = t.clock.now.Add(d)
+	t.clock.waiters[t.id] = t
+	return active
+}
+
+type manualTicker struct{ *manualTimer }
+
+func (t manualTicker) Stop() { t.manualTimer.Stop() }
+
+func (c *ManualClock) schedule(d, period time.Duration) *manualTimer {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.seq++
+	var t = &manualTimer{clock: c, id: c.seq, deadline: c.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	c.waiters[t.id] = t
+	return t
+}
+
+// Now current time of the clock
+func (c *ManualClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+// Sleep blocks until the clock is advanced by d
+func (c *ManualClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-c.schedule(d, 0).c
+}
+
+// NewTimer timer fired when the clock is advanced by d
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	return c.schedule(d, 0)
+}
+
+// NewTicker ticker fired every time the clock passes next period
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return manualTicker{c.schedule(d, d)}
+}
+
+// Advance moves the clock forward and fires timers and tickers in order of their deadlines
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	var target = c.now.Add(d)
+	for {
+		var due []*manualTimer
+		for _, t := range c.waiters {
+			if !t.deadline.After(target) {
+				due = append(due, t)
+			}
+		}
+		if len(due) == 0 {
+			break
+		}
+		sort.Slice(due, func(i, j int) bool {
+			if due[i].deadline.Equal(due[j].deadline) {
+				return due[i].id < due[j].id
+			}
+			return due[i].deadline.Before(due[j].deadline)
+		})
+		var t = due[0]
+		c.now = t.deadline
+		select {
+		case t.c <- c.now:
+		default: // like time.Ticker the tick is dropped for slow receiver
+		}
+		if t.period > 0 {
+			t.deadline = t.deadline.Add(t.period)
+		} else {
+			delete(c.waiters, t.id)
+		}
+	}
+	c.now = target
+}
+
+// Pending number of active timers, tickers and sleeps, it lets test await the driver to start waiting
+func (c *ManualClock) Pending() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return len(c.waiters)
+}
@@ -0,0 +1,141 @@
+package transport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecwid/control/transport"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const none = -1
+
+func TestManualClockAdvance(t *testing.T) {
+	var cases = []struct {
+		name     string
+		timers   []time.Duration
+		tickers  []time.Duration
+		advances []time.Duration
+		// fired milliseconds since epoch received by timers and then tickers after each advance, none if nothing
+		fired [][]int
+	}{
+		{
+			name:     "timers fire at their deadlines",
+			timers:   []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond},
+			advances: []time.Duration{25 * time.Millisecond, 5 * time.Millisecond, time.Hour},
+			fired:    [][]int{{none, 10, 20}, {30, none, none}, {none, none, none}},
+		},
+		{
+			name:     "zero timer fires on the next advance",
+			timers:   []time.Duration{0},
+			advances: []time.Duration{0, 0},
+			fired:    [][]int{{0}, {none}},
+		},
+		{
+			name:     "ticker drops ticks for slow receiver",
+			tickers:  []time.Duration{10 * time.Millisecond},
+			advances: []time.Duration{5 * time.Millisecond, 25 * time.Millisecond, 10 * time.Millisecond},
+			fired:    [][]int{{none}, {10}, {40}},
+		},
+		{
+			name:     "timers and tickers are interleaved",
+			timers:   []time.Duration{15 * time.Millisecond},
+			tickers:  []time.Duration{10 * time.Millisecond},
+			advances: []time.Duration{10 * time.Millisecond, 10 * time.Millisecond},
+			fired:    [][]int{{none, 10}, {15, 20}},
+		},
+	}
+	for _, c := range cases {
+		var (
+			clock    = transport.NewManualClock(epoch)
+			channels []<-chan time.Time
+		)
+		for _, d := range c.timers {
+			channels = append(channels, clock.NewTimer(d).C())
+		}
+		for _, d := range c.tickers {
+			channels = append(channels, clock.NewTicker(d).C())
+		}
+		var elapsed time.Duration
+		for step, d := range c.advances {
+			clock.Advance(d)
+			elapsed += d
+			if now := clock.Now(); !now.Equal(epoch.Add(elapsed)) {
+				t.Errorf("%s: step %d: clock is at %s, expected %s", c.name, step, now.Sub(epoch), elapsed)
+			}
+			for i, ch := range channels {
+				var got = none
+				select {
+				case v := <-ch:
+					got = int(v.Sub(epoch) / time.Millisecond)
+				default:
+				}
+				if got != c.fired[step][i] {
+					t.Errorf("%s: step %d: channel %d received %d, expected %d", c.name, step, i, got, c.fired[step][i])
+				}
+			}
+		}
+	}
+}
+
+func TestManualClockStopReset(t *testing.T) {
+	var clock = transport.NewManualClock(epoch)
+	var stopped, reset = clock.NewTimer(time.Second), clock.NewTimer(time.Second)
+	if !stopped.Stop() || stopped.Stop() {
+		t.Fatal("only the first Stop of active timer returns true")
+	}
+	clock.Advance(time.Millisecond * 500)
+	if !reset.Reset(time.Second) {
+		t.Fatal("Reset of active timer returns true")
+	}
+	clock.Advance(time.Millisecond * 999)
+	select {
+	case <-reset.C():
+		t.Fatal("reset timer fired before its new deadline")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	select {
+	case v := <-reset.C():
+		if !v.Equal(epoch.Add(time.Millisecond * 1500)) {
+			t.Fatalf("reset timer fired at %s", v.Sub(epoch))
+		}
+	default:
+		t.Fatal("reset timer didn't fire")
+	}
+	select {
+	case <-stopped.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Fatalf("expected no pending timers, got %d", n)
+	}
+}
+
+func TestManualClockSleep(t *testing.T) {
+	var (
+		clock = transport.NewManualClock(epoch)
+		woken = make(chan struct{})
+	)
+	go func() {
+		clock.Sleep(time.Minute)
+		close(woken)
+	}()
+	for clock.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Minute - time.Nanosecond)
+	select {
+	case <-woken:
+		t.Fatal("sleep returned before the clock passed its duration")
+	case <-time.After(time.Millisecond * 10):
+	}
+	clock.Advance(time.Nanosecond)
+	select {
+	case <-woken:
+	case <-time.After(time.Second):
+		t.Fatal("sleep didn't return")
+	}
+}
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DirectionSend = "->"
+	DirectionRecv = "<-"
+)
+
+// WireLogger protocol messages logger with method filters, truncation and sampling
+type WireLogger struct {
+	Writer io.Writer
+	// Include log only these methods (if not empty), `Network.*` matches all methods of domain
+	Include []string
+	// Exclude don't log these methods
+	Exclude []string
+	// MaxSize truncate message to MaxSize bytes (0 - no truncation)
+	MaxSize int
+	// Sample log every Nth message of the method pattern, e.g. {"Network.*": 10}
+	Sample map[string]int
+	// Hex dump messages instead of JSON
+	Hex bool
+
+	mx      sync.Mutex
+	counter map[string]int
+	runID   string
+}
+
+// SetRunID correlation id of the run written to every line as `run=<id>` (empty disables),
+// see BrowserContext.SetRunID
+func (w *WireLogger) SetRunID(id string) {
+	if w == nil {
+		return
+	}
+	w.mx.Lock()
+	w.runID = id
+	w.mx.Unlock()
+}
+
+func matchMethod(pattern, method string) bool {
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(method, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == method || pattern == "*"
+}
+
+func (w *WireLogger) accept(method string) bool {
+	if len(w.Include) > 0 {
+		var included = false
+		for _, p := range w.Include {
+			if matchMethod(p, method) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, p := range w.Exclude {
+		if matchMethod(p, method) {
+			return false
+		}
+	}
+	for p, n := range w.Sample {
+		if n > 1 && matchMethod(p, method) {
+			w.mx.Lock()
+			if w.counter == nil {
+				w.counter = map[string]int{}
+			}
+			c := w.counter[p]
+			w.counter[p]++
+			w.mx.Unlock()
+			return c%n == 0
+		}
+	}
+	return true
+}
+
+func (w *WireLogger) log(direction, method string, data []byte) {
+	if w == nil || w.Writer == nil || !w.accept(method) {
+		return
+	}
+	var suffix = ""
+	if w.MaxSize > 0 && len(data) > w.MaxSize {
+		suffix = fmt.Sprintf("... (%d bytes truncated)", len(data)-w.MaxSize)
+		data = data[:w.MaxSize]
+	}
+	var body = string(data)
+	if w.Hex {
+		body = "\n" + hex.Dump(data)
+	}
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	var prefix = time.Now().Format("15:04:05.000")
+	if w.runID != "" {
+		prefix += " run=" + w.runID
+	}
+	_, _ = fmt.Fprintf(w.Writer, "%s %s %s %s%s\n", prefix, direction, method, body, suffix)
+}
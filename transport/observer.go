@@ -46,6 +46,12 @@ func (o *Publisher) Unregister(val Observer) {
 	delete(o.observers, val.ID())
 }
 
+func (o *Publisher) UnregisterAll() {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	o.observers = map[string]Observer{}
+}
+
 func NewSimpleObserver(id, event string, update func(value Event)) SimpleObserver {
 	return SimpleObserver{
 		id:     id,
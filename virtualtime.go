@@ -0,0 +1,43 @@
+package control
+
+import (
+	"time"
+
+	"github.com/ecwid/control/protocol/emulation"
+	"github.com/ecwid/control/transport"
+)
+
+const (
+	VirtualTimeAdvance                      emulation.VirtualTimePolicy = "advance"
+	VirtualTimePause                        emulation.VirtualTimePolicy = "pause"
+	VirtualTimePauseIfNetworkFetchesPending emulation.VirtualTimePolicy = "pauseIfNetworkFetchesPending"
+)
+
+// SetVirtualTimePolicy switch the page to virtual time with the policy, if budget is set then
+// Emulation.virtualTimeBudgetExpired is emitted when budget is spent and virtual time is paused
+func (e Emulation) SetVirtualTimePolicy(policy emulation.VirtualTimePolicy, budget time.Duration) (*emulation.SetVirtualTimePolicyVal, error) {
+	return emulation.SetVirtualTimePolicy(e.s, emulation.SetVirtualTimePolicyArgs{
+		Policy: policy,
+		Budget: float64(budget) / float64(time.Millisecond),
+	})
+}
+
+// PauseClock pause virtual time of the page, timers and animation frames don't fire until AdvanceClock
+func (s Session) PauseClock() error {
+	_, err := s.Emulation.SetVirtualTimePolicy(VirtualTimePause, 0)
+	return err
+}
+
+// AdvanceClock fast-forward virtual time of the page by d (setTimeout, requestAnimationFrame and Date follow it)
+// and pause it again. Virtual time doesn't advance while network fetches are pending
+func (s Session) AdvanceClock(d time.Duration) error {
+	future := s.Observe("Emulation.virtualTimeBudgetExpired", func(value transport.Event, resolve func(interface{}), reject func(error)) {
+		resolve(nil)
+	})
+	defer future.Cancel()
+	if _, err := s.Emulation.SetVirtualTimePolicy(VirtualTimePauseIfNetworkFetchesPending, d); err != nil {
+		return err
+	}
+	_, err := future.get(s.browser.Client.Timeout, nil)
+	return err
+}
@@ -0,0 +1,176 @@
+package control
+
+import (
+	"encoding/json"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/transport"
+)
+
+const waitPollingInterval = time.Millisecond * 100
+
+// Condition reusable readiness condition, it's bound to the session when wait starts:
+// check reports whether condition is satisfied, cancel releases condition's resources (e.g. subscriptions)
+type Condition func(s Session) (check func() (bool, error), cancel func())
+
+// Wait poll condition until it is satisfied
+func (s Session) Wait(condition Condition, timeout time.Duration) error {
+	check, cancel := condition(s)
+	defer cancel()
+	var (
+		clock    = s.Clock()
+		deadline = clock.NewTimer(timeout)
+		ticker   = clock.NewTicker(waitPollingInterval)
+	)
+	defer deadline.Stop()
+	defer ticker.Stop()
+	var failed = s.pageErrors.failFastC()
+	for {
+		ok, err := check()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-failed:
+			return s.pageErrors.last()
+		case <-ticker.C():
+		case <-deadline.C():
+			return FutureTimeoutError{timeout: timeout}
+		case <-s.context.Done():
+			return s.context.Err()
+		}
+	}
+}
+
+func bindAll(s Session, conditions []Condition) ([]func() (bool, error), func()) {
+	var (
+		checks  = make([]func() (bool, error), len(conditions))
+		cancels = make([]func(), len(conditions))
+	)
+	for i, c := range conditions {
+		checks[i], cancels[i] = c(s)
+	}
+	return checks, func() {
+		for _, c := range cancels {
+			c()
+		}
+	}
+}
+
+// WaitAll satisfied when all conditions are satisfied
+func WaitAll(conditions ...Condition) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		checks, cancel := bindAll(s, conditions)
+		return func() (bool, error) {
+			for _, check := range checks {
+				if ok, err := check(); err != nil || !ok {
+					return false, err
+				}
+			}
+			return true, nil
+		}, cancel
+	}
+}
+
+// WaitAny satisfied when at least one of conditions is satisfied
+func WaitAny(conditions ...Condition) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		checks, cancel := bindAll(s, conditions)
+		return func() (bool, error) {
+			for _, check := range checks {
+				if ok, err := check(); err != nil || ok {
+					return ok, err
+				}
+			}
+			return false, nil
+		}, cancel
+	}
+}
+
+// Not satisfied when condition is not satisfied
+func Not(condition Condition) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		check, cancel := condition(s)
+		return func() (bool, error) {
+			ok, err := check()
+			return !ok, err
+		}, cancel
+	}
+}
+
+// ElementVisible satisfied when element matched by selector exists in main frame and has non-empty box
+func ElementVisible(selector string) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		return elementVisible(s.Page(), selector), func() {}
+	}
+}
+
+// elementVisible check of ElementVisible in the frame
+func elementVisible(f *Frame, selector string) func() (bool, error) {
+	return func() (bool, error) {
+		el, err := f.QuerySelector(selector)
+		switch err.(type) {
+		case nil:
+		case NoSuchElementError:
+			return false, nil
+		default:
+			return false, err
+		}
+		if _, err = el.GetContentQuad(false); err != nil {
+			if err == ErrNodeIsNotVisible || err == ErrNodeIsOutOfViewport {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// URLMatches satisfied when current url of main frame is matched by re
+func URLMatches(re *regexp.Regexp) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		return func() (bool, error) {
+			entry, err := s.Page().GetNavigationEntry()
+			if err != nil {
+				return false, err
+			}
+			return re.MatchString(entry.Url), nil
+		}, func() {}
+	}
+}
+
+// RequestFinished satisfied when request with url matched by re finished loading after wait starts
+func RequestFinished(re *regexp.Regexp) Condition {
+	return func(s Session) (func() (bool, error), func()) {
+		var (
+			matched  = &sync.Map{}
+			finished int32
+		)
+		cancel := s.Subscribe("*", func(value transport.Event) {
+			switch value.Method {
+			case "Network.requestWillBeSent":
+				var sent = network.RequestWillBeSent{}
+				if err := json.Unmarshal(value.Params, &sent); err == nil && re.MatchString(sent.Request.Url) {
+					matched.Store(sent.RequestId, true)
+				}
+			case "Network.loadingFinished":
+				var done = network.LoadingFinished{}
+				if err := json.Unmarshal(value.Params, &done); err == nil {
+					if _, ok := matched.Load(done.RequestId); ok {
+						atomic.StoreInt32(&finished, 1)
+					}
+				}
+			}
+		})
+		return func() (bool, error) {
+			return atomic.LoadInt32(&finished) == 1, nil
+		}, cancel
+	}
+}
@@ -0,0 +1,98 @@
+package control
+
+import (
+	"sync/atomic"
+
+	"github.com/ecwid/control/protocol/network"
+	"github.com/ecwid/control/protocol/runtime"
+	"github.com/ecwid/control/protocol/target"
+)
+
+const (
+	targetTypeWorker        = "worker"
+	targetTypeSharedWorker  = "shared_worker"
+	targetTypeServiceWorker = "service_worker"
+)
+
+// Worker dedicated, shared or service worker of the page.
+// It's a session without Page domain: use Subscribe to receive its Runtime.consoleAPICalled or Network events
+type Worker struct {
+	*Session
+	Type string
+	URL  string
+}
+
+// Evaluate evaluate expression in the worker global scope
+func (w Worker) Evaluate(expression string, await, returnByValue bool) (interface{}, error) {
+	val, err := runtime.Evaluate(w, runtime.EvaluateArgs{
+		Expression:    expression,
+		AwaitPromise:  await,
+		ReturnByValue: returnByValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	return val.Result.Value, nil
+}
+
+// attachWorker set up session of the worker paused on start, hooks are called before the worker script runs
+func (s Session) attachWorker(v target.AttachedToTarget) {
+	defer s.resume(v)
+	var worker = &Worker{
+		Session: s.browser.newSession(v.TargetInfo.TargetId, v.SessionId),
+		Type:    v.TargetInfo.Type,
+		URL:     v.TargetInfo.Url,
+	}
+	var err error
+	defer func() {
+		if err != nil {
+			// as runSession does: worker that failed to set up is released, lifecycle exits on detach
+			worker.lifecycleState.set(StateClosed, err)
+			s.browser.releaseSession(worker.Session)
+			_ = target.DetachFromTarget(s.browser, target.DetachFromTargetArgs{SessionId: v.SessionId})
+		}
+	}()
+	if err = runtime.Enable(worker); err != nil {
+		return
+	}
+	if err = worker.optional(network.Enable(worker, network.EnableArgs{})); err != nil {
+		return
+	}
+	worker.lifecycleState.set(StateReady, nil)
+	s.workers.Store(v.TargetInfo.TargetId, worker)
+	worker.OnStateChange(func(_, to SessionState) {
+		if to.IsTerminal() {
+			s.workers.Delete(v.TargetInfo.TargetId)
+		}
+	})
+	if worker.State().IsTerminal() { // detached before the hook is registered
+		s.workers.Delete(v.TargetInfo.TargetId)
+		return
+	}
+	s.workerHooks.Range(func(_, hook interface{}) bool {
+		hook.(func(*Worker))(worker)
+		return true
+	})
+}
+
+// Workers returns workers currently attached to the page
+func (s Session) Workers() []*Worker {
+	var workers []*Worker
+	s.workers.Range(func(_, value interface{}) bool {
+		workers = append(workers, value.(*Worker))
+		return true
+	})
+	return workers
+}
+
+// OnWorkerAttached register hook called when new worker of the page is attached and ready
+func (s Session) OnWorkerAttached(hook func(*Worker)) (cancel func()) {
+	var uid = atomic.AddUint64(s.guid, 1)
+	s.workerHooks.Store(uid, hook)
+	return func() {
+		s.workerHooks.Delete(uid)
+	}
+}
@@ -0,0 +1,37 @@
+package control
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ecwid/control/protocol/target"
+	"github.com/ecwid/control/transport"
+	"github.com/ecwid/control/transport/cdptest"
+)
+
+func TestAttachWorkerFailure(t *testing.T) {
+	const workerTargetID, workerSessionID = "WT", "WS"
+	s, srv, log := testSession(t,
+		cdptest.Message{Direction: transport.DirectionSend, Method: "Runtime.enable", Data: json.RawMessage(`{"id":1,"sessionId":"` + workerSessionID + `","method":"Runtime.enable"}`)},
+		cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(`{"id":1,"sessionId":"` + workerSessionID + `","error":{"code":-32000,"message":"failed"}}`)},
+		cdptest.Message{Direction: transport.DirectionSend, Method: "Target.detachFromTarget", Data: json.RawMessage(`{"id":2,"method":"Target.detachFromTarget"}`)},
+		cdptest.Message{Direction: transport.DirectionRecv, Data: json.RawMessage(`{"id":2,"result":{}}`)},
+	)
+	s.attachWorker(target.AttachedToTarget{
+		SessionId:  workerSessionID,
+		TargetInfo: &target.TargetInfo{TargetId: workerTargetID, Type: targetTypeWorker},
+	})
+	played(t, srv)
+	if _, ok := s.browser.sessions.Load(target.TargetID(workerTargetID)); ok {
+		t.Fatal("session of worker that failed to set up is not released")
+	}
+	if workers := s.Workers(); len(workers) != 0 {
+		t.Fatalf("unexpected workers %v", workers)
+	}
+	var detach target.DetachFromTargetArgs
+	if sent := log.sent(t, "Target.detachFromTarget"); len(sent) != 1 {
+		t.Fatalf("expected worker to be detached, got %d calls", len(sent))
+	} else if err := json.Unmarshal(sent[0], &detach); err != nil || detach.SessionId != workerSessionID {
+		t.Fatalf("unexpected detach %s", sent[0])
+	}
+}
@@ -0,0 +1,55 @@
+package control
+
+import (
+	"github.com/ecwid/control/protocol/common"
+	"github.com/ecwid/control/protocol/page"
+	"github.com/ecwid/control/protocol/runtime"
+)
+
+type worldKey struct {
+	frame common.FrameId
+	name  string
+}
+
+// EvaluateInIsolatedWorld evaluate expression in the isolated world of main frame
+func (s Session) EvaluateInIsolatedWorld(name, expression string, await, returnByValue bool) (interface{}, error) {
+	return s.Page().EvaluateInIsolatedWorld(name, expression, await, returnByValue)
+}
+
+// EvaluateInIsolatedWorld evaluate expression in the isolated world with given name,
+// the world is created on first use and shares DOM but not JS globals with the page scripts
+func (f Frame) EvaluateInIsolatedWorld(name, expression string, await, returnByValue bool) (interface{}, error) {
+	cid, err := f.isolatedWorld(name)
+	if err != nil {
+		return nil, err
+	}
+	val, err := runtime.Evaluate(f, runtime.EvaluateArgs{
+		Expression:    expression,
+		ContextId:     cid,
+		AwaitPromise:  await,
+		ReturnByValue: returnByValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if val.ExceptionDetails != nil {
+		return nil, RuntimeError(*val.ExceptionDetails)
+	}
+	return val.Result.Value, nil
+}
+
+func (f Frame) isolatedWorld(name string) (runtime.ExecutionContextId, error) {
+	var key = worldKey{frame: f.id, name: name}
+	if cid, ok := f.session.worlds.Load(key); ok {
+		return cid.(runtime.ExecutionContextId), nil
+	}
+	val, err := page.CreateIsolatedWorld(f, page.CreateIsolatedWorldArgs{
+		FrameId:   f.id,
+		WorldName: name,
+	})
+	if err != nil {
+		return 0, err
+	}
+	f.session.worlds.Store(key, val.ExecutionContextId)
+	return val.ExecutionContextId, nil
+}